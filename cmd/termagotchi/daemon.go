@@ -0,0 +1,131 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ezeoleaf/termagotchi/internal/app"
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// runDaemon keeps the pets ticking in the background until interrupted.
+func runDaemon(args []string) error {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	return app.RunDaemon(cfg, app.DaemonOptions{})
+}
+
+// runServe runs the daemon with the HTTP API on top, for dashboards.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:7070", "address to serve the HTTP API on")
+	token := fs.String("token", os.Getenv("TERMAGOTCHI_TOKEN"), "token the HTTP API asks for (default $TERMAGOTCHI_TOKEN, or a new one)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *token == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return err
+		}
+		*token = hex.EncodeToString(b)
+		log.Printf("HTTP API token: %s", *token)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	return app.RunDaemon(cfg, app.DaemonOptions{APIAddr: *addr, APIToken: *token})
+}
+
+// runStatus shows how the pets are doing: live from the daemon when it is
+// running, as last saved otherwise.
+func runStatus(args []string, activePet string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	pet := fs.String("pet", activePet, "only show this pet")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var statuses []app.PetStatus
+	if c := daemonClient(); c != nil {
+		defer c.Close()
+		var err error
+		if statuses, err = c.Statuses(*pet); err != nil {
+			return err
+		}
+	} else {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if statuses, err = app.PetStatuses(cfg, *pet); err != nil {
+			return err
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTAGE\tAGE\tHUNGER\tHAPPINESS\tHEALTH\tENERGY\tCOINS\tSTATE")
+	for _, s := range statuses {
+		name := s.Name
+		if s.Current {
+			name += " *"
+		}
+		state := "alive"
+		switch {
+		case !s.Alive:
+			state = "passed away"
+		case s.Sick:
+			state = "sick"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%dd\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			name, s.Stage, s.Age, s.Hunger, s.Happiness, s.Health, s.Energy, s.Coins, state)
+	}
+	return w.Flush()
+}
+
+// daemonClient connects to the daemon if one is running.
+func daemonClient() *app.Client {
+	c, err := app.DialDaemon()
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
+// lockSave claims the save for a command that changes it, so that a game
+// running on it can't write over the change when it next saves.
+func lockSave() (*config.SaveLock, error) {
+	lock, err := config.LockSave()
+	if err != nil {
+		return nil, fmt.Errorf("%w: close it first, or run the daemon and open the game as its client", err)
+	}
+	return lock, nil
+}
+
+// loadConfig loads the save, having a running daemon write it first so it
+// is up to date.
+func loadConfig() (*config.Config, error) {
+	if c := daemonClient(); c != nil {
+		err := c.Save()
+		c.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return config.LoadConfig()
+}
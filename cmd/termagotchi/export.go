@@ -0,0 +1,241 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/app"
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+func runExport(args []string, activePet string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "csv", "output format: csv or json")
+	pet := fs.String("pet", activePet, "pet to export; defaults to the active pet")
+	sinceFlag := fs.String("since", "", "only include history from this date (YYYY-MM-DD or RFC 3339)")
+	untilFlag := fs.String("until", "", "only include history up to this date (YYYY-MM-DD or RFC 3339)")
+	output := fs.String("output", "", "file to write; defaults to standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	write, ok := map[string]func(io.Writer, *config.Export) error{
+		"csv":  config.WriteExportCSV,
+		"json": config.WriteExportJSON,
+	}[strings.ToLower(*format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	since, err := parseDate(*sinceFlag, false)
+	if err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+	until, err := parseDate(*untilFlag, true)
+	if err != nil {
+		return fmt.Errorf("--until: %w", err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := *pet
+	if name == "" {
+		name = cfg.App.ActivePet
+	}
+	if name == "" && len(cfg.Pets) > 0 {
+		name = cfg.Pets[0].Name
+	}
+
+	export := &config.Export{Version: config.ExportVersion, Exported: time.Now()}
+	for i := range cfg.Pets {
+		if cfg.Pets[i].Name == name {
+			export.Pet = &cfg.Pets[i]
+		}
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	records, err := config.ReadJournal(dir)
+	if err != nil {
+		log.Printf("skipping what can't be read of the event journal: %v", err)
+	}
+	for _, rec := range records {
+		if rec.Pet != name || (!since.IsZero() && rec.Timestamp.Before(since)) || (!until.IsZero() && rec.Timestamp.After(until)) {
+			continue
+		}
+		export.Events = append(export.Events, rec)
+	}
+
+	export.Mode = config.ModeNormal
+	if export.Pet != nil {
+		export.Mode = export.Pet.Mode()
+	} else {
+		graves, err := config.LoadGraveyard()
+		if err != nil {
+			return err
+		}
+		for _, g := range graves {
+			if g.Name == name {
+				export.Mode = g.Mode()
+			}
+		}
+	}
+
+	if export.Pet != nil {
+		stats, err := config.LoadStats()
+		if err != nil {
+			return err
+		}
+		export.Stats = config.ExportStats(stats[export.Pet.ID], since, until)
+	} else if len(export.Events) == 0 {
+		return fmt.Errorf("no pet named %q", name)
+	}
+
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return write(out, export)
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "input format: csv or json; guessed from the file name when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: termagotchi import [--format csv|json] FILE")
+	}
+	path := fs.Arg(0)
+
+	if c := daemonClient(); c != nil {
+		c.Close()
+		return fmt.Errorf("the daemon is running and would overwrite the import; stop it first")
+	}
+	lock, err := lockSave()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	if *format == "" {
+		*format = "json"
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			*format = "csv"
+		}
+	}
+	read, ok := map[string]func(io.Reader) (*config.Export, error){
+		"csv":  config.ReadExportCSV,
+		"json": config.ReadExportJSON,
+	}[strings.ToLower(*format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	export, err := read(f)
+	if err != nil {
+		return err
+	}
+	if export.Pet == nil {
+		return fmt.Errorf("%s has no pet to restore; only pets still in the roster can be imported", path)
+	}
+	// The file could have been edited, so the pet is marked as tampered, for
+	// good, and its care streaks start over.
+	pet := *export.Pet
+	now := time.Now()
+	pet.Tampered = true
+	pet.StreakReset = now
+	export.Events = append(export.Events, config.EventRecord{
+		Pet:       pet.Name,
+		Type:      "TAMPER",
+		Message:   fmt.Sprintf("%s was imported from a file, so it is marked as tampered and its care streaks start over. ⚠️", pet.Name),
+		Timestamp: now,
+	})
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := app.AddImportedPet(cfg, pet, now); err != nil {
+		return err
+	}
+
+	stats, err := config.LoadStats()
+	if err != nil {
+		return err
+	}
+	if stats == nil {
+		stats = make(map[string][]config.StatTier)
+	}
+	stats[pet.ID] = export.StatTiers()
+	if err := config.SaveStats(stats); err != nil {
+		return err
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	journal, err := config.OpenJournal(dir)
+	if err != nil {
+		return err
+	}
+	for _, rec := range export.Events {
+		if err := journal.Append(rec); err != nil {
+			journal.Close()
+			return err
+		}
+	}
+	if err := journal.Close(); err != nil {
+		return err
+	}
+
+	if err := config.SaveConfig(cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %s with %d events and %d stat samples. It is marked as tampered, since the file could have been edited.\n", pet.Name, len(export.Events)-1, len(export.Stats))
+	return nil
+}
+
+// parseDate accepts a day or a full timestamp. A bare day used as an upper
+// bound covers the whole day.
+func parseDate(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC 3339, got %q", s)
+	}
+	if endOfDay {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return day, nil
+}
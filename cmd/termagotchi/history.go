@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+var stageRank = map[string]int{"egg": 0, "baby": 1, "child": 2, "teen": 3, "adult": 4}
+
+// graveLess orders graves by each sortable column of the history table.
+var graveLess = map[string]func(a, b config.GraveConfig) bool{
+	"name":       func(a, b config.GraveConfig) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"born":       func(a, b config.GraveConfig) bool { return a.Born.Before(b.Born) },
+	"died":       func(a, b config.GraveConfig) bool { return a.Died.Before(b.Died) },
+	"lifespan":   func(a, b config.GraveConfig) bool { return a.Lifespan() < b.Lifespan() },
+	"stage":      func(a, b config.GraveConfig) bool { return stageRank[a.Stage] < stageRank[b.Stage] },
+	"generation": func(a, b config.GraveConfig) bool { return a.Generation < b.Generation },
+	"cause":      func(a, b config.GraveConfig) bool { return a.Cause < b.Cause },
+	"happiness":  func(a, b config.GraveConfig) bool { return a.Peak.Happiness < b.Peak.Happiness },
+	"health":     func(a, b config.GraveConfig) bool { return a.Peak.Health < b.Peak.Health },
+	"weight":     func(a, b config.GraveConfig) bool { return a.Peak.Weight < b.Peak.Weight },
+}
+
+func runHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	sortBy := fs.String("sort", "died", "column to sort by: name, born, died, lifespan, stage, generation, cause, happiness, health, weight")
+	reverse := fs.Bool("reverse", false, "sort in descending order")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	less, ok := graveLess[strings.ToLower(*sortBy)]
+	if !ok {
+		return fmt.Errorf("unknown sort column %q", *sortBy)
+	}
+
+	graves, err := config.LoadGraveyard()
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(graves, func(i, j int) bool {
+		if *reverse {
+			return less(graves[j], graves[i])
+		}
+		return less(graves[i], graves[j])
+	})
+
+	return printHistory(os.Stdout, graves)
+}
+
+func printHistory(out io.Writer, graves []config.GraveConfig) error {
+	if len(graves) == 0 {
+		_, err := fmt.Fprintln(out, "No pets have passed on yet.")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMODE\tBORN\tDIED\tLIFESPAN\tSTAGE\tGENERATION\tCAUSE\tHAPPINESS\tHEALTH\tWEIGHT")
+	for _, g := range graves {
+		stage := g.Stage
+		if g.Form != "" {
+			stage = fmt.Sprintf("%s (%s)", g.Stage, g.Form)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%d\t%d\t%.1fg\n",
+			g.Name,
+			g.Mode(),
+			g.Born.Format("2006-01-02 15:04"),
+			g.Died.Format("2006-01-02 15:04"),
+			formatLifespan(g.Lifespan()),
+			stage,
+			g.Generation,
+			g.Cause,
+			g.Peak.Happiness,
+			g.Peak.Health,
+			g.Peak.Weight,
+		)
+	}
+	return w.Flush()
+}
+
+func formatLifespan(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	hours := (d % (24 * time.Hour)) / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/ezeoleaf/termagotchi/internal/app"
@@ -8,11 +9,109 @@ import (
 )
 
 func main() {
+	pet := flag.String("pet", "", "name of the pet to care for; adopts a new one if it doesn't exist")
+	difficulty := flag.String("difficulty", "", "balance preset for new pets from now on: relaxed, classic or hardcore")
+	flag.Parse()
+	if *difficulty != "" {
+		if err := app.CheckDifficulty(*difficulty); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	switch flag.Arg(0) {
+	case "history":
+		if err := runHistory(flag.Args()[1:]); err != nil {
+			log.Fatalf("history: %v", err)
+		}
+		return
+	case "export":
+		if err := runExport(flag.Args()[1:], *pet); err != nil {
+			log.Fatalf("export: %v", err)
+		}
+		return
+	case "report":
+		if err := runReport(flag.Args()[1:], *pet); err != nil {
+			log.Fatalf("report: %v", err)
+		}
+		return
+	case "vacation":
+		if err := runVacation(flag.Args()[1:]); err != nil {
+			log.Fatalf("vacation: %v", err)
+		}
+		return
+	case "sitter":
+		if err := runSitter(flag.Args()[1:]); err != nil {
+			log.Fatalf("sitter: %v", err)
+		}
+		return
+	case "notify":
+		if err := runNotify(flag.Args()[1:]); err != nil {
+			log.Fatalf("notify: %v", err)
+		}
+		return
+	case "webhook":
+		if err := runWebhook(flag.Args()[1:]); err != nil {
+			log.Fatalf("webhook: %v", err)
+		}
+		return
+	case "import":
+		if err := runImport(flag.Args()[1:]); err != nil {
+			log.Fatalf("import: %v", err)
+		}
+		return
+	case "daemon":
+		if err := runDaemon(flag.Args()[1:]); err != nil {
+			log.Fatalf("daemon: %v", err)
+		}
+		return
+	case "serve":
+		if err := runServe(flag.Args()[1:]); err != nil {
+			log.Fatalf("serve: %v", err)
+		}
+		return
+	case "status":
+		if err := runStatus(flag.Args()[1:], *pet); err != nil {
+			log.Fatalf("status: %v", err)
+		}
+		return
+	}
+
+	// With the daemon running the game is its client. Otherwise it runs the
+	// save itself, and only one game may.
+	c := daemonClient()
+	if c == nil {
+		lock, err := lockSave()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() { _ = lock.Release() }()
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	if *pet != "" {
+		cfg.App.ActivePet = *pet
+	}
+
+	if c != nil {
+		if *difficulty != "" {
+			log.Printf("ignoring --difficulty: the daemon is running, set it with the daemon stopped")
+		}
+		a, err := app.NewClientApp(cfg, c)
+		if err != nil {
+			log.Fatalf("failed to connect to the daemon: %v", err)
+		}
+		a.Run()
+		return
+	}
+
+	if *difficulty != "" {
+		cfg.App.Difficulty = *difficulty
+	}
+
 	a := app.NewApp(cfg)
 	a.Run()
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/ezeoleaf/termagotchi/internal/app"
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// runNotify turns notifications on or off, changes their settings or sends a
+// test alert.
+func runNotify(args []string) error {
+	fs := flag.NewFlagSet("notify", flag.ExitOnError)
+	backends := fs.String("backends", "", "where to send alerts, comma separated: bell, desktop, command")
+	command := fs.String("command", "", "shell command run by the command backend, given TERMAGOTCHI_PET, TERMAGOTCHI_ALERT and TERMAGOTCHI_MESSAGE")
+	hungerAt := fs.Int("hunger-at", 0, "alert when hunger reaches this (1-100)")
+	healthAt := fs.Int("health-at", 0, "alert when health drops to this (1-100)")
+	quiet := fs.String("quiet", "", "quiet hours like 22:00-07:00, or off")
+	every := fs.Duration("every", 0, "send the same alert for a pet at most this often")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	c := daemonClient()
+	if c != nil {
+		defer c.Close()
+		if err := c.Save(); err != nil {
+			return err
+		}
+	} else if fs.Arg(0) == "on" || fs.Arg(0) == "off" || (fs.Arg(0) == "" && len(set) > 0) {
+		lock, err := lockSave()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lock.Release() }()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	notify := app.WithNotifyDefaults(cfg.App.Notify)
+
+	if set["backends"] {
+		notify.Backends = strings.Split(*backends, ",")
+	}
+	if set["command"] {
+		notify.Command = *command
+	}
+	if set["hunger-at"] {
+		notify.HungerAt = *hungerAt
+	}
+	if set["health-at"] {
+		notify.HealthAt = *healthAt
+	}
+	if set["quiet"] {
+		notify.QuietFrom, notify.QuietUntil = "", ""
+		if *quiet != "off" {
+			from, until, ok := strings.Cut(*quiet, "-")
+			if !ok {
+				return fmt.Errorf("--quiet takes a range like 22:00-07:00, or off")
+			}
+			notify.QuietFrom, notify.QuietUntil = from, until
+		}
+	}
+	if set["every"] {
+		if *every <= 0 {
+			return fmt.Errorf("--every must be positive")
+		}
+		notify.Every = *every
+	}
+
+	switch fs.Arg(0) {
+	case "":
+		if len(set) == 0 {
+			fmt.Println(app.DescribeNotify(notify))
+			return nil
+		}
+	case "on":
+		notify.Enabled = true
+	case "off":
+		notify.Enabled = false
+	case "test":
+		if err := app.ValidateNotify(notify); err != nil {
+			return err
+		}
+		return app.SendTestNotification(notify)
+	default:
+		return fmt.Errorf("usage: termagotchi notify [--backends LIST] [--command CMD] [--hunger-at N] [--health-at N] [--quiet FROM-UNTIL] [--every D] [on|off|test]")
+	}
+	if err := app.ValidateNotify(notify); err != nil {
+		return err
+	}
+
+	if c != nil {
+		description, err := c.SetNotify(notify)
+		if err != nil {
+			return err
+		}
+		fmt.Println(description)
+		return nil
+	}
+
+	cfg.App.Notify = notify
+	if err := config.SaveConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Println(app.DescribeNotify(notify))
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/ezeoleaf/termagotchi/internal/app"
+)
+
+func runReport(args []string, activePet string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	pet := fs.String("pet", activePet, "only report on this pet")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	report, err := app.YesterdaysReport(cfg, *pet)
+	if err != nil {
+		return err
+	}
+	fmt.Print(report)
+	return nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/app"
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// runVacation starts, ends or shows the vacation. Changes take effect the
+// next time the game runs or catches up, or straight away in the daemon.
+// They are refused while the game is open on its own, as it would write its
+// own vacation over them when it saves.
+func runVacation(args []string) error {
+	fs := flag.NewFlagSet("vacation", flag.ExitOnError)
+	days := fs.Int("days", 7, "length of the vacation in days")
+	mode := fs.String("mode", "freeze", "freeze stops decay, slow makes it 4 times slower")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	usage := fmt.Errorf("usage: termagotchi vacation [--days N] [--mode freeze|slow] [start|end]")
+
+	// The daemon would overwrite changes made to the save behind its back.
+	if c := daemonClient(); c != nil {
+		defer c.Close()
+		var status string
+		var err error
+		switch fs.Arg(0) {
+		case "":
+			err = c.Save()
+		case "start":
+			status, err = c.StartVacation(*mode, *days)
+		case "end":
+			status, err = c.EndVacation()
+		default:
+			return usage
+		}
+		if err != nil {
+			return err
+		}
+		if status != "" {
+			fmt.Println(status)
+			return nil
+		}
+	}
+
+	if fs.Arg(0) != "" {
+		lock, err := lockSave()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lock.Release() }()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	switch fs.Arg(0) {
+	case "":
+		fmt.Println(app.VacationStatus(cfg, now))
+		return nil
+	case "start":
+		message, err := app.StartVacation(cfg, *mode, *days, now)
+		if err != nil {
+			return err
+		}
+		if err := logToPets(cfg, "VACATION", message, now); err != nil {
+			return err
+		}
+	case "end":
+		if err := app.EndVacation(cfg, now); err != nil {
+			return err
+		}
+	default:
+		return usage
+	}
+
+	if err := config.SaveConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Println(app.VacationStatus(cfg, now))
+	return nil
+}
+
+// runSitter turns the pet sitter on or off and sets its thresholds, through
+// the daemon or with the game closed, like runVacation.
+func runSitter(args []string) error {
+	fs := flag.NewFlagSet("sitter", flag.ExitOnError)
+	feedAt := fs.Int("feed-at", 0, "feed when hunger reaches this (1-100)")
+	sleepAt := fs.Int("sleep-at", 0, "put to sleep when energy drops to this (1-100)")
+	buyFood := fs.Bool("buy-food", false, "buy food with the pet's coins when out of stock")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c := daemonClient()
+	if c != nil {
+		defer c.Close()
+		if err := c.Save(); err != nil {
+			return err
+		}
+	} else if fs.Arg(0) != "" {
+		lock, err := lockSave()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lock.Release() }()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	sitter := app.WithSitterDefaults(cfg.App.Sitter)
+
+	switch fs.Arg(0) {
+	case "":
+		fmt.Println(app.DescribeSitter(sitter))
+		return nil
+	case "on":
+		sitter.Enabled = true
+	case "off":
+		sitter.Enabled = false
+	default:
+		return fmt.Errorf("usage: termagotchi sitter [--feed-at N] [--sleep-at N] [--buy-food] [on|off]")
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["feed-at"] {
+		if sitter.FeedAt, err = threshold("feed-at", *feedAt); err != nil {
+			return err
+		}
+	}
+	if set["sleep-at"] {
+		if sitter.SleepAt, err = threshold("sleep-at", *sleepAt); err != nil {
+			return err
+		}
+	}
+	if set["buy-food"] {
+		sitter.BuyFood = *buyFood
+	}
+
+	if c != nil {
+		description, err := c.SetSitter(sitter)
+		if err != nil {
+			return err
+		}
+		fmt.Println(description)
+		return nil
+	}
+
+	cfg.App.Sitter = sitter
+	if err := config.SaveConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Println(app.DescribeSitter(sitter))
+	return nil
+}
+
+func threshold(name string, v int) (int, error) {
+	if v < 1 || v > 100 {
+		return 0, fmt.Errorf("--%s must be between 1 and 100", name)
+	}
+	return v, nil
+}
+
+// logToPets appends an event for every living pet to the journal.
+func logToPets(cfg *config.Config, eventType, message string, at time.Time) error {
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	journal, err := config.OpenJournal(dir)
+	if err != nil {
+		return err
+	}
+	for _, pet := range cfg.Pets {
+		if !pet.IsAlive {
+			continue
+		}
+		rec := config.EventRecord{Pet: pet.Name, Type: eventType, Message: message, Timestamp: at}
+		if err := journal.Append(rec); err != nil {
+			journal.Close()
+			return err
+		}
+	}
+	return journal.Close()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ezeoleaf/termagotchi/internal/app"
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+const webhookUsage = "usage: termagotchi webhook [add --url URL --events TYPES [--preset slack|discord] [--template T] NAME | remove NAME | test NAME]"
+
+// runWebhook lists, adds, removes or tests the webhooks that post events.
+func runWebhook(args []string) error {
+	action := ""
+	if len(args) > 0 {
+		action, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("webhook "+action, flag.ExitOnError)
+	url := fs.String("url", "", "URL to post to")
+	events := fs.String("events", "", "event types to post, comma separated, such as DEATH,EVOLUTION,ALERT")
+	preset := fs.String("preset", "", "post in the format of slack or discord incoming webhooks")
+	template := fs.String("template", "", "JSON body as a Go template of the event's .Pet, .Type, .Message, .Icon and .Timestamp, with json to quote")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c := daemonClient()
+	if c != nil {
+		defer c.Close()
+		if err := c.Save(); err != nil {
+			return err
+		}
+	} else if action == "add" || action == "remove" {
+		lock, err := lockSave()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lock.Release() }()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	hooks := cfg.App.Webhooks
+	name := fs.Arg(0)
+	found := slices.IndexFunc(hooks, func(h config.WebhookConfig) bool { return h.Name == name })
+
+	switch {
+	case action == "":
+		printWebhooks(hooks)
+		return nil
+	case name == "":
+		return errors.New(webhookUsage)
+	case action == "add":
+		h := config.WebhookConfig{Name: name, URL: *url, Preset: *preset, Template: *template}
+		if *events != "" {
+			h.Events = strings.Split(*events, ",")
+		}
+		if err := app.ValidateWebhook(h); err != nil {
+			return err
+		}
+		if found >= 0 {
+			hooks[found] = h
+		} else {
+			hooks = append(hooks, h)
+		}
+	case action == "remove" || action == "test":
+		if found < 0 {
+			return fmt.Errorf("no webhook named %q", name)
+		}
+		if action == "test" {
+			return app.SendTestWebhook(hooks[found])
+		}
+		hooks = slices.Delete(hooks, found, found+1)
+	default:
+		return errors.New(webhookUsage)
+	}
+
+	if c != nil {
+		if _, err := c.SetWebhooks(hooks); err != nil {
+			return err
+		}
+	} else {
+		cfg.App.Webhooks = hooks
+		if err := config.SaveConfig(cfg); err != nil {
+			return err
+		}
+	}
+	printWebhooks(hooks)
+	return nil
+}
+
+func printWebhooks(hooks []config.WebhookConfig) {
+	if len(hooks) == 0 {
+		fmt.Println("No webhooks")
+	}
+	for _, h := range hooks {
+		fmt.Println(app.DescribeWebhook(h))
+	}
+}
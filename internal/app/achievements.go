@@ -0,0 +1,342 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+const (
+	// elderAge is the age in days at which a pet counts as an elder.
+	elderAge = 30
+
+	// healthyHealth is the health a pet has to stay above for the healthy
+	// day achievement.
+	healthyHealth = 90
+)
+
+var stageOrder = []string{"egg", "baby", "child", "teen", "adult"}
+
+// achievement is one entry of the catalog. progressLocked reports how far
+// the player has got towards goal and is called with stateMu and
+// achievementsMu held. Reward is paid in coins to the pet that earns it.
+type achievement struct {
+	ID             string
+	Name           string
+	Icon           string
+	Description    string
+	Goal           int
+	Reward         int
+	progressLocked func(a *App) int
+}
+
+var achievementCatalog = []achievement{
+	{
+		ID:          "first_evolution",
+		Name:        "Growing Up",
+		Icon:        "🐣",
+		Description: "Watch a pet evolve for the first time",
+		Goal:        1,
+		Reward:      20,
+		progressLocked: func(a *App) int {
+			return min(1, a.bestPetLocked(func(t *Tamagotchi) int { return stageIndex(t.Stage) }))
+		},
+	},
+	{
+		ID:          "reach_adult",
+		Name:        "All Grown Up",
+		Icon:        "🧑",
+		Description: "Raise a pet to adulthood",
+		Goal:        len(stageOrder) - 1,
+		Reward:      50,
+		progressLocked: func(a *App) int {
+			return a.bestPetLocked(func(t *Tamagotchi) int { return stageIndex(t.Stage) })
+		},
+	},
+	{
+		ID:          "care_streak",
+		Name:        "Devoted Carer",
+		Icon:        "🔥",
+		Description: "Keep up a 7-day streak of good care",
+		Goal:        7,
+		Reward:      50,
+		progressLocked: func(a *App) int {
+			return a.bestPetLocked(func(t *Tamagotchi) int { return careStreak(t.Care, t.StreakReset) })
+		},
+	},
+	{
+		ID:          "gourmet",
+		Name:        "Gourmet",
+		Icon:        "🍽️",
+		Description: "Serve every kind of food",
+		Goal:        len(availableFoods),
+		Reward:      30,
+		progressLocked: func(a *App) int {
+			tried := 0
+			for _, food := range availableFoods {
+				if a.achievements.foodsTried[food.Name] {
+					tried++
+				}
+			}
+			return tried
+		},
+	},
+	{
+		ID:          "healthy_day",
+		Name:        "Picture of Health",
+		Icon:        "💪",
+		Description: fmt.Sprintf("Keep a pet's health above %d for 24 hours", healthyHealth),
+		Goal:        24,
+		Reward:      30,
+		progressLocked: func(a *App) int {
+			return a.bestPetLocked(func(t *Tamagotchi) int {
+				since, ok := a.achievements.healthySince[t.ID]
+				if !ok || !t.IsAlive {
+					return 0
+				}
+				return int(a.achievements.observedAt.Sub(since).Hours())
+			})
+		},
+	},
+	{
+		ID:          "elder",
+		Name:        "Golden Years",
+		Icon:        "👴",
+		Description: fmt.Sprintf("Keep a pet alive to elder age (%d days)", elderAge),
+		Goal:        elderAge,
+		Reward:      100,
+		progressLocked: func(a *App) int {
+			return a.bestPetLocked(func(t *Tamagotchi) int {
+				if !t.IsAlive {
+					return 0
+				}
+				return t.Age
+			})
+		},
+	},
+}
+
+// unlock records who earned an achievement and when.
+type unlock struct {
+	Pet string
+	At  time.Time
+}
+
+// achievementState is guarded by achievementsMu. It is taken after stateMu
+// and eventsMu, and never held while logging an event.
+type achievementState struct {
+	unlocked     map[string]unlock
+	foodsTried   map[string]bool
+	healthySince map[string]time.Time // pet ID -> when health last rose above healthyHealth
+	announce     []string             // IDs unlocked but not yet shown
+	observedAt   time.Time            // time of the last tick observed
+}
+
+func findAchievement(id string) (achievement, bool) {
+	for _, ach := range achievementCatalog {
+		if ach.ID == id {
+			return ach, true
+		}
+	}
+	return achievement{}, false
+}
+
+func stageIndex(stage string) int {
+	for i, s := range stageOrder {
+		if s == stage {
+			return i
+		}
+	}
+	return 0
+}
+
+// bestPetLocked returns the highest value of f across the roster.
+func (a *App) bestPetLocked(f func(t *Tamagotchi) int) int {
+	best := 0
+	for _, t := range a.pets {
+		best = max(best, f(t))
+	}
+	return best
+}
+
+// tasteFoodLocked records that t was served a food, and returns the
+// achievements that unlocked, to be announced once the feeding is logged.
+func (a *App) tasteFoodLocked(t *Tamagotchi, food string, at time.Time) []achievement {
+	a.achievementsMu.Lock()
+	defer a.achievementsMu.Unlock()
+
+	a.achievements.foodsTried[food] = true
+	return a.reachedAchievementsLocked(t, at)
+}
+
+// observeTickLocked updates achievement progress after a tick of t.
+func (a *App) observeTickLocked(t *Tamagotchi, at time.Time) {
+	a.achievementsMu.Lock()
+	a.achievements.observedAt = at
+	if _, ok := a.achievements.healthySince[t.ID]; !ok && t.IsAlive && t.Health > healthyHealth {
+		a.achievements.healthySince[t.ID] = at
+	} else if !t.IsAlive || t.Health <= healthyHealth {
+		delete(a.achievements.healthySince, t.ID)
+	}
+	reached := a.reachedAchievementsLocked(t, at)
+	a.achievementsMu.Unlock()
+
+	a.announceAchievements(reached, t.Name, at)
+}
+
+// reachedAchievementsLocked unlocks every achievement whose goal has been
+// reached, crediting t and paying it the reward, and returns them.
+func (a *App) reachedAchievementsLocked(t *Tamagotchi, at time.Time) []achievement {
+	var reached []achievement
+	for _, ach := range achievementCatalog {
+		if _, ok := a.achievements.unlocked[ach.ID]; ok {
+			continue
+		}
+		if ach.progressLocked(a) < ach.Goal {
+			continue
+		}
+		t.Coins += ach.Reward
+		a.achievements.unlocked[ach.ID] = unlock{Pet: t.Name, At: at}
+		a.achievements.announce = append(a.achievements.announce, ach.ID)
+		reached = append(reached, ach)
+	}
+	return reached
+}
+
+func (a *App) announceAchievements(reached []achievement, pet string, at time.Time) {
+	for _, ach := range reached {
+		a.addPetEventAt(pet, "ACHIEVEMENT", fmt.Sprintf("Achievement unlocked: %s %s - %s. +%d coins", ach.Icon, ach.Name, ach.Description, ach.Reward), at)
+	}
+}
+
+// showAchievementModal announces the oldest achievement not yet shown.
+func (a *App) showAchievementModal() {
+	if a.modal != nil {
+		return // Modal already showing
+	}
+
+	a.achievementsMu.Lock()
+	if len(a.achievements.announce) == 0 {
+		a.achievementsMu.Unlock()
+		return
+	}
+	id := a.achievements.announce[0]
+	a.achievements.announce = a.achievements.announce[1:]
+	got := a.achievements.unlocked[id]
+	a.achievementsMu.Unlock()
+
+	ach, ok := findAchievement(id)
+	if !ok {
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("🏆 Achievement unlocked!\n\n%s %s\n%s\n\nEarned by %s", ach.Icon, ach.Name, ach.Description, got.Pet)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.TApp.SetRoot(a.TLayout, true).SetFocus(a.TLayout)
+			a.modal = nil
+			a.showAchievementModal()
+		})
+
+	a.modal = modal
+	a.TApp.SetRoot(modal, true).SetFocus(modal)
+}
+
+func (a *App) generateAchievementsList(listAchievements *tview.List) {
+	listAchievements.Clear()
+
+	type row struct {
+		ach      achievement
+		got      unlock
+		unlocked bool
+		progress int
+	}
+
+	a.stateMu.RLock()
+	a.achievementsMu.Lock()
+	rows := make([]row, 0, len(achievementCatalog))
+	for _, ach := range achievementCatalog {
+		got, unlocked := a.achievements.unlocked[ach.ID]
+		rows = append(rows, row{ach: ach, got: got, unlocked: unlocked, progress: ach.progressLocked(a)})
+	}
+	a.achievementsMu.Unlock()
+	a.stateMu.RUnlock()
+
+	done := 0
+	for _, r := range rows {
+		if r.unlocked {
+			done++
+		}
+	}
+	listAchievements.AddItem(fmt.Sprintf("=== ACHIEVEMENTS (%d/%d) ===", done, len(rows)), "", 0, nil)
+	listAchievements.AddItem("", "", 0, nil) // Empty line
+
+	for _, r := range rows {
+		if r.unlocked {
+			listAchievements.AddItem(fmt.Sprintf("🏆 %s %s - %s (%d coins)", r.ach.Icon, r.ach.Name, r.ach.Description, r.ach.Reward), "", 0, nil)
+			listAchievements.AddItem(fmt.Sprintf("   Unlocked %s by %s", r.got.At.Format("2006-01-02 15:04"), r.got.Pet), "", 0, nil)
+			continue
+		}
+
+		progress := min(r.progress, r.ach.Goal)
+		listAchievements.AddItem(fmt.Sprintf("🔒 %s %s - %s (%d coins)", r.ach.Icon, r.ach.Name, r.ach.Description, r.ach.Reward), "", 0, nil)
+		listAchievements.AddItem(fmt.Sprintf("   %s %d/%d", a.createProgressBar(progress*100/r.ach.Goal, 100), progress, r.ach.Goal), "", 0, nil)
+	}
+}
+
+func (a *App) achievementsPage() (title string, content tview.Primitive) {
+	listAchievements := a.viewsList["achievements"]
+	if listAchievements == nil {
+		listAchievements = getList()
+		a.viewsList["achievements"] = listAchievements
+	}
+
+	a.generateAchievementsList(listAchievements)
+
+	title = achievementsSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listAchievements, 0, 1, true), 0, 1, true)
+}
+
+func achievementsFromConfig(cfg config.AchievementsConfig) achievementState {
+	state := achievementState{
+		unlocked:     make(map[string]unlock, len(cfg.Unlocked)),
+		foodsTried:   make(map[string]bool, len(cfg.FoodsTried)),
+		healthySince: make(map[string]time.Time, len(cfg.HealthySince)),
+	}
+	for _, u := range cfg.Unlocked {
+		state.unlocked[u.ID] = unlock{Pet: u.Pet, At: u.At}
+	}
+	for _, food := range cfg.FoodsTried {
+		state.foodsTried[food] = true
+	}
+	for id, since := range cfg.HealthySince {
+		state.healthySince[id] = since
+	}
+	return state
+}
+
+func (a *App) achievementsToConfig() config.AchievementsConfig {
+	a.achievementsMu.Lock()
+	defer a.achievementsMu.Unlock()
+
+	cfg := config.AchievementsConfig{HealthySince: make(map[string]time.Time, len(a.achievements.healthySince))}
+	for id, u := range a.achievements.unlocked {
+		cfg.Unlocked = append(cfg.Unlocked, config.UnlockConfig{ID: id, Pet: u.Pet, At: u.At})
+	}
+	sort.Slice(cfg.Unlocked, func(i, j int) bool { return cfg.Unlocked[i].At.Before(cfg.Unlocked[j].At) })
+	for food := range a.achievements.foodsTried {
+		cfg.FoodsTried = append(cfg.FoodsTried, food)
+	}
+	sort.Strings(cfg.FoodsTried)
+	for id, since := range a.achievements.healthySince {
+		cfg.HealthySince[id] = since
+	}
+	return cfg
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGourmetPaysItsReward(t *testing.T) {
+	clock := newTestClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	a := newTestApp(t, clock)
+
+	var coins int
+	name := setCurrentPet(a, func(t *Tamagotchi) {
+		for _, food := range availableFoods {
+			t.Inventory[food.Name] = 1
+		}
+		t.Quests = t.activeQuests(clock.now())
+		for i := range t.Quests {
+			t.Quests[i].Done = clock.now() // so quest rewards don't add to the coins
+		}
+		coins = t.Coins
+	})
+	for _, food := range availableFoods {
+		if err := a.feedPet(name, food.Name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ach, _ := findAchievement("gourmet")
+	a.achievementsMu.Lock()
+	got, ok := a.achievements.unlocked[ach.ID]
+	a.achievementsMu.Unlock()
+	if !ok || got.Pet != name {
+		t.Fatalf("gourmet unlocked = %v by %q, want unlocked by %q", ok, got.Pet, name)
+	}
+	a.stateMu.RLock()
+	left := a.findPetLocked(name).Coins
+	a.stateMu.RUnlock()
+	if left != coins+ach.Reward {
+		t.Errorf("coins = %d, want %d", left, coins+ach.Reward)
+	}
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The HTTP API serves the same game as the control socket to dashboards and
+// scripts: pet state, the catalogs, care, and a Server-Sent Events stream of
+// events. Every endpoint but the OpenAPI description needs the token, as a
+// bearer token or, for EventSource clients that can't set headers, a token
+// query parameter.
+
+// apiKeepAlive is how often the event stream sends a comment so idle
+// connections aren't dropped.
+const apiKeepAlive = 30 * time.Second
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+// apiHandler routes the HTTP API, letting in requests with the token.
+func (a *App) apiHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPISpec)
+	})
+
+	api := http.NewServeMux()
+	api.HandleFunc("GET /api/pets", a.apiPets)
+	api.HandleFunc("GET /api/pets/{pet}", a.apiPet)
+	api.HandleFunc("POST /api/pets/{pet}/feed", a.apiAction(methodFeed))
+	api.HandleFunc("POST /api/pets/{pet}/play", a.apiAction(methodPlay))
+	api.HandleFunc("POST /api/pets/{pet}/sleep", a.apiAction(methodSleep))
+	api.HandleFunc("GET /api/foods", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, foodCatalog(a.now()))
+	})
+	api.HandleFunc("GET /api/games", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, availableGames)
+	})
+	api.HandleFunc("GET /api/sleep-options", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, sleepOptions)
+	})
+	api.HandleFunc("GET /api/events", a.apiEvents)
+	mux.Handle("/api/", requireToken(token, api))
+
+	return mux
+}
+
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given := r.URL.Query().Get("token")
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			given = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or wrong token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *App) apiPets(w http.ResponseWriter, r *http.Request) {
+	statuses, err := a.statuses("")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if statuses == nil {
+		statuses = []PetStatus{}
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (a *App) apiPet(w http.ResponseWriter, r *http.Request) {
+	statuses, err := a.statuses(r.PathValue("pet"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statuses[0])
+}
+
+// apiAction takes care of the pet in the path, as the body says: which food,
+// game or sleep option.
+func (a *App) apiAction(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pet := r.PathValue("pet")
+		if _, err := a.statuses(pet); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		var p actionParams
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("bad request body: %w", err))
+			return
+		}
+		p.Pet = pet
+		if err := a.runAction(method, p); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+
+		statuses, err := a.statuses(pet)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, statuses[0])
+	}
+}
+
+// apiEvents streams events, of one pet with ?pet= or of every pet, as
+// Server-Sent Events until the client goes away.
+func (a *App) apiEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	events, cancel := a.subscribe(r.URL.Query().Get("pet"))
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(apiKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
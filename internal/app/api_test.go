@@ -0,0 +1,198 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testToken = "secret"
+
+func newTestAPI(t *testing.T) (*App, *httptest.Server) {
+	t.Helper()
+	clock := newTestClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	a := newTestApp(t, clock)
+	srv := httptest.NewServer(a.apiHandler(testToken))
+	t.Cleanup(srv.Close)
+	return a, srv
+}
+
+// apiRequest sends a request with the token and decodes the JSON answer into
+// out, returning the status.
+func apiRequest(t *testing.T, method, target, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, target, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestAPIToken(t *testing.T) {
+	_, srv := newTestAPI(t)
+
+	tests := []struct {
+		name   string
+		query  string
+		header string
+		want   int
+	}{
+		{"no token", "", "", http.StatusUnauthorized},
+		{"wrong header", "", "Bearer nope", http.StatusUnauthorized},
+		{"not a bearer token", "", "Basic " + testToken, http.StatusUnauthorized},
+		{"wrong query", "?token=nope", "", http.StatusUnauthorized},
+		{"wrong header beats right query", "?token=" + testToken, "Bearer nope", http.StatusUnauthorized},
+		{"header", "", "Bearer " + testToken, http.StatusOK},
+		{"query", "?token=" + url.QueryEscape(testToken), "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/pets"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	resp, err := http.Get(srv.URL + "/api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("openapi.json without a token: status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestAPIPets(t *testing.T) {
+	a, srv := newTestAPI(t)
+	name := setCurrentPet(a, func(t *Tamagotchi) { t.Hunger = 42 })
+
+	var pets []PetStatus
+	if status := apiRequest(t, http.MethodGet, srv.URL+"/api/pets", "", &pets); status != http.StatusOK {
+		t.Fatalf("GET /api/pets: status %d", status)
+	}
+	if len(pets) != 1 || pets[0].Name != name || pets[0].Hunger != 42 {
+		t.Errorf("GET /api/pets = %+v, want %s with hunger 42", pets, name)
+	}
+
+	var pet PetStatus
+	if status := apiRequest(t, http.MethodGet, srv.URL+"/api/pets/"+url.PathEscape(name), "", &pet); status != http.StatusOK {
+		t.Fatalf("GET /api/pets/%s: status %d", name, status)
+	}
+	if pet.Name != name {
+		t.Errorf("GET /api/pets/%s = %+v", name, pet)
+	}
+
+	var apiErr map[string]string
+	if status := apiRequest(t, http.MethodGet, srv.URL+"/api/pets/Nobody", "", &apiErr); status != http.StatusNotFound {
+		t.Errorf("GET /api/pets/Nobody: status %d, want %d", status, http.StatusNotFound)
+	}
+	if apiErr["error"] == "" {
+		t.Error("GET /api/pets/Nobody: no error message")
+	}
+}
+
+func TestAPIFeedOutOfStock(t *testing.T) {
+	a, srv := newTestAPI(t)
+	food := availableFoods[0].Name
+	name := setCurrentPet(a, func(t *Tamagotchi) {
+		t.Inventory = map[string]int{food: 1}
+		t.Hunger = 90
+	})
+	target := srv.URL + "/api/pets/" + url.PathEscape(name) + "/feed"
+	body := `{"food": "` + food + `"}`
+
+	var pet PetStatus
+	if status := apiRequest(t, http.MethodPost, target, body, &pet); status != http.StatusOK {
+		t.Fatalf("first feed: status %d, want %d", status, http.StatusOK)
+	}
+	if pet.Hunger >= 90 {
+		t.Errorf("hunger after feeding = %d, want below 90", pet.Hunger)
+	}
+
+	var apiErr map[string]string
+	if status := apiRequest(t, http.MethodPost, target, body, &apiErr); status != http.StatusConflict {
+		t.Errorf("feed out of stock: status %d, want %d", status, http.StatusConflict)
+	}
+	if !strings.Contains(apiErr["error"], "out of stock") {
+		t.Errorf("feed out of stock: error %q", apiErr["error"])
+	}
+
+	if status := apiRequest(t, http.MethodPost, target, "{", nil); status != http.StatusBadRequest {
+		t.Errorf("bad body: status %d, want %d", status, http.StatusBadRequest)
+	}
+	if status := apiRequest(t, http.MethodPost, srv.URL+"/api/pets/Nobody/feed", body, nil); status != http.StatusNotFound {
+		t.Errorf("feed nobody: status %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestAPIEvents(t *testing.T) {
+	a, srv := newTestAPI(t)
+	name := setCurrentPet(a, func(t *Tamagotchi) {})
+
+	resp, err := http.Get(srv.URL + "/api/events?token=" + testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type %q, want text/event-stream", ct)
+	}
+
+	// The headers arrive once the handler has subscribed, so the event
+	// can't be missed.
+	a.addPetEvent(name, "PLAY", "Played with the test!")
+
+	got := make(chan GameEvent, 1)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var event GameEvent
+			if err := json.Unmarshal([]byte(data), &event); err == nil {
+				got <- event
+				return
+			}
+		}
+	}()
+
+	select {
+	case event := <-got:
+		if event.Pet != name || event.Type != "PLAY" || event.Message != "Played with the test!" {
+			t.Errorf("event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event on the stream")
+	}
+}
@@ -3,6 +3,8 @@ package app
 import (
 	"fmt"
 	"log"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 
@@ -11,101 +13,349 @@ import (
 	"github.com/rivo/tview"
 )
 
-const defaultUpdateInterval = 30 * time.Second
+const (
+	defaultUpdateInterval = 30 * time.Second
+
+	// maxRecentEvents is how many events per pet are kept in memory.
+	maxRecentEvents = 50
+)
 
 // App contains the tview application, the layout for the display, and the loaded config
 type App struct {
 	TApp              *tview.Application
 	TLayout           *tview.Flex
 	Config            *config.Config
+	clock             func() time.Time
 	viewsList         map[string]*tview.List
 	spriteView        *tview.TextView
 	currentTamagotchi *Tamagotchi
-	gameEvents        []GameEvent
+	pets              []*Tamagotchi
+	lineage           []LineageEntry
+	graves            []config.GraveConfig
+	journal           *config.Journal
+	stats             map[string]statSeries  // per pet ID
+	gameEvents        map[string][]GameEvent // per pet name
 	modal             *tview.Modal
+	eventsFilter      eventFilter
+	eventsLimit       int
+	eventsHistory     eventsHistory
+	chartWindow       int
+	careCheckedDay    time.Time // day the care scores were last brought up to date
+	achievements      achievementState
+
+	worldEvents        config.WorldEventsConfig
+	nextWorldEvent     time.Time
+	worldRand          *rand.Rand  // when world events happen and which; guarded by stateMu
+	pendingWorldEvent  *worldEvent // waiting for the player's choice
+	catchingUp         bool        // ticks are offline catch-up
+	offlineWorldEvents []string    // summaries of the events that fired while catching up
+	recordingOffline   bool        // events are copied to offlineEvents; guarded by eventsMu
+	offlineEvents      []GameEvent // events logged while catching up; guarded by eventsMu
+	welcomeBack        string      // summary of the catch-up shown on launch, if any
+
+	vacation        config.VacationConfig
+	vacationMode    string // mode picked on the Vacation page
+	sitter          config.SitterConfig
+	sitterOutOfFood map[string]bool // pet IDs the sitter already reported out of food this catch-up
+	notify          config.NotifyConfig
+	webhooks        []config.WebhookConfig
+	webhookInbox    *webhookInbox // events logged for the webhooks to look at
+
+	remote      *Client                   // the daemon this game is a client of, if any
+	headless    bool                      // run by the daemon, with no player to ask
+	subscribers map[chan GameEvent]string // event streams by pet, "" for all; guarded by eventsMu
 
 	stateMu         sync.RWMutex
+	configMu        sync.Mutex
 	eventsMu        sync.Mutex
+	achievementsMu  sync.Mutex
 	uiMu            sync.Mutex
 	tuiRunning      bool
 	uiReady         bool
 	uiReadyOnce     sync.Once
 	needsRefresh    bool
 	timeAccumulator time.Duration
-	updateInterval  time.Duration
 }
 
 // NewApp returns an instance of the application, initialized with the provided config
 func NewApp(cfg *config.Config) *App {
-	app := &App{
-		TApp:           tview.NewApplication(),
-		Config:         cfg,
-		viewsList:      make(map[string]*tview.List),
-		gameEvents:     make([]GameEvent, 0),
-		updateInterval: defaultUpdateInterval,
-	}
+	return NewAppWithClock(cfg, time.Now)
+}
 
+// NewAppWithClock is NewApp with the game's idea of the current time given by
+// clock, so dates such as holidays can be tried out.
+func NewAppWithClock(cfg *config.Config, clock func() time.Time) *App {
+	app := newApp(cfg, clock)
 	app.initializeStateFromConfig()
+	app.buildLayout()
+
+	// Start the game loop
+	go app.gameLoop()
+
+	return app
+}
+
+func newApp(cfg *config.Config, clock func() time.Time) *App {
+	return &App{
+		TApp:         tview.NewApplication(),
+		Config:       cfg,
+		clock:        clock,
+		vacationMode: vacationFreeze,
+		viewsList:    make(map[string]*tview.List),
+		gameEvents:   make(map[string][]GameEvent),
+		subscribers:  make(map[chan GameEvent]string),
+		webhookInbox: newWebhookInbox(),
+		worldRand:    rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
 
-	pages, info := app.getPagesInfo()
+// buildLayout sets up the pages and key bindings from the game state.
+func (a *App) buildLayout() {
+	pages, info := a.getPagesInfo()
 
 	layout := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(pages, 0, 1, true).
 		AddItem(info, 1, 1, false)
 
-	app.TApp.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+	a.TApp.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyCtrlH:
-			app.goToSection(helpSection, info)
+			// Help describes the current pet, which may have changed.
+			a.generateHelpList(a.viewsList["help"])
+			a.goToSection(helpSection, info)
 		case tcell.KeyCtrlS:
-			app.goToSection(statusSection, info)
+			a.goToSection(statusSection, info)
 		case tcell.KeyCtrlF:
-			app.goToSection(feedSection, info)
+			a.goToSection(feedSection, info)
 		case tcell.KeyCtrlP:
-			app.goToSection(playSection, info)
+			a.goToSection(playSection, info)
 		case tcell.KeyCtrlL:
-			app.goToSection(sleepSection, info)
+			a.goToSection(sleepSection, info)
 		case tcell.KeyCtrlE:
-			app.goToSection(eventsSection, info)
+			a.goToSection(eventsSection, info)
+		case tcell.KeyCtrlB:
+			a.goToSection(shopSection, info)
+		case tcell.KeyCtrlT:
+			a.goToSection(itemsSection, info)
+		case tcell.KeyCtrlA:
+			a.goToSection(petsSection, info)
+		case tcell.KeyCtrlN:
+			a.switchToNextPet()
+		case tcell.KeyCtrlG:
+			a.goToSection(familySection, info)
+		case tcell.KeyCtrlO:
+			a.goToSection(memorialSection, info)
+		case tcell.KeyCtrlK:
+			a.goToSection(chartsSection, info)
+		case tcell.KeyCtrlU:
+			a.goToSection(achievementsSection, info)
+		case tcell.KeyCtrlV:
+			a.goToSection(vacationSection, info)
 		case tcell.KeyCtrlR:
-			app.showRestartModal()
+			a.showRestartModal()
 		}
 		return event
 	})
 
-	app.TLayout = layout
-
-	// Start the game loop
-	go app.gameLoop()
-
-	return app
+	a.TLayout = layout
 }
 
 func (a *App) initializeStateFromConfig() {
-	if a.Config.App.LastLogin.IsZero() {
-		a.currentTamagotchi = newDefaultTamagotchi(randomName())
-		a.updateConfigFromState()
+	wanted := a.Config.App.ActivePet
+
+	a.achievements = achievementsFromConfig(a.Config.Achievements)
+
+	// A name that belonged to a pet that came before can't be adopted again,
+	// since event logs are keyed by name.
+	a.lineage = lineageFromConfig(a.Config.Lineage)
+	if wanted != "" && a.nameTakenLocked(wanted) && !slices.ContainsFunc(a.Config.Pets, func(p config.TamagotchiConfig) bool { return p.Name == wanted }) {
+		log.Printf("not adopting %s: a pet that came before had that name", wanted)
+		wanted = ""
+	}
+
+	if a.Config.App.LastLogin.IsZero() || len(a.Config.Pets) == 0 {
+		name := wanted
+		if name == "" {
+			name = a.uniqueNameLocked()
+		}
+		a.pets = []*Tamagotchi{newDefaultTamagotchi(name, a.newPetBalance(), a.now())}
 	} else {
-		cfg := a.Config.Tamagotchi
-		a.currentTamagotchi = &Tamagotchi{
-			Name:      cfg.Name,
-			Age:       cfg.Age,
-			Hunger:    cfg.Hunger,
-			Happiness: cfg.Happiness,
-			Health:    cfg.Health,
-			Energy:    cfg.Energy,
-			Weight:    cfg.Weight,
-			Stage:     cfg.Stage,
-			Created:   cfg.Created,
-			LastFed:   cfg.LastFed,
-			LastPlay:  cfg.LastPlay,
-			LastSleep: cfg.LastSleep,
-			IsAlive:   cfg.IsAlive,
+		for _, cfg := range a.Config.Pets {
+			a.pets = append(a.pets, tamagotchiFromConfig(cfg))
 		}
 	}
 
+	a.openJournal()
+
+	a.loadHistory()
+
+	a.checkSaveIntegrity()
+	a.checkClockOnLoad()
+
+	for _, t := range a.pets {
+		a.recordLineageLocked(t)
+	}
+
+	a.currentTamagotchi = a.findPetLocked(wanted)
+	if a.currentTamagotchi == nil {
+		a.currentTamagotchi = a.pets[0]
+	}
+
+	a.loadWorldEvents()
+	a.vacation = a.Config.App.Vacation
+	a.sitter = a.Config.App.Sitter
+	a.notify = a.Config.App.Notify
+	a.webhooks = a.Config.App.Webhooks
+
+	// Catching up logs events before the webhooks start running, and they
+	// are owed those too.
+	a.webhookInbox.open()
 	a.applyOfflineProgress()
+
+	// Asking for a pet that doesn't exist yet adopts it, after the others
+	// have caught up so the newcomer starts fresh.
+	if a.findPetLocked(wanted) == nil && wanted != "" && len(a.pets) < maxPets {
+		a.currentTamagotchi = newDefaultTamagotchi(wanted, a.newPetBalance(), a.now())
+		a.pets = append(a.pets, a.currentTamagotchi)
+		a.recordLineageLocked(a.currentTamagotchi)
+	}
+
+	a.scoreCareDays(a.now())
+
+	a.updateConfigFromState()
+}
+
+// loadHistory loads the graveyard and the stat history.
+func (a *App) loadHistory() {
+	graves, err := config.LoadGraveyard()
+	if err != nil {
+		log.Printf("failed to load graveyard: %v", err)
+	}
+	stats, err := config.LoadStats()
+	if err != nil {
+		log.Printf("failed to load stat history: %v", err)
+	}
+
+	a.stateMu.Lock()
+	a.graves = graves
+	a.stats = statsFromConfig(stats)
+	a.stateMu.Unlock()
+}
+
+func tamagotchiFromConfig(cfg config.TamagotchiConfig) *Tamagotchi {
+	t := &Tamagotchi{
+		ID:        cfg.ID,
+		Name:      cfg.Name,
+		Age:       cfg.Age,
+		Hunger:    cfg.Hunger,
+		Happiness: cfg.Happiness,
+		Health:    cfg.Health,
+		Energy:    cfg.Energy,
+		Weight:    cfg.Weight,
+		Stage:     cfg.Stage,
+		Form:      cfg.Form,
+		Created:   cfg.Created,
+		LastFed:   cfg.LastFed,
+		LastPlay:  cfg.LastPlay,
+		LastSleep: cfg.LastSleep,
+		IsAlive:   cfg.IsAlive,
+		Coins:     cfg.Coins,
+		Inventory: copyInventory(cfg.Inventory),
+		Equipped:  copyEquipped(cfg.Equipped),
+		Skills:    skillsFromConfig(cfg.Skills),
+
+		Genes:      Genes{Color: cfg.Genes.Color, Traits: append([]string(nil), cfg.Genes.Traits...)},
+		Parents:    append([]string(nil), cfg.Parents...),
+		Generation: cfg.Generation,
+		LastBred:   cfg.LastBred,
+
+		Peak: Peak{
+			Happiness: cfg.Peak.Happiness,
+			Health:    cfg.Peak.Health,
+			Energy:    cfg.Peak.Energy,
+			Weight:    cfg.Peak.Weight,
+			Coins:     cfg.Peak.Coins,
+		},
+
+		Care:   careFromConfig(cfg.Care),
+		Quests: questsFromConfig(cfg.Quests),
+
+		Celebrated: copyCelebrated(cfg.Celebrated),
+
+		Balance:  balanceOrClassic(cfg.Balance),
+		Hardcore: cfg.Hardcore,
+		Tampered: cfg.Tampered,
+		Sick:     cfg.Sick,
+
+		StreakReset: cfg.StreakReset,
+	}
+
+	// Saves from before the shop existed have no inventory at all.
+	if cfg.Inventory == nil {
+		t.Inventory = starterInventory()
+	}
+
+	// Pets from before breeding existed get an identity and genes.
+	if t.ID == "" {
+		t.ID = newPetID()
+	}
+	if t.Genes.Color == "" {
+		t.Genes = randomGenes()
+	}
+	t.recordPeaks()
+
+	return t
+}
+
+func tamagotchiToConfig(t Tamagotchi) config.TamagotchiConfig {
+	cfg := config.TamagotchiConfig{
+		ID:        t.ID,
+		Name:      t.Name,
+		Age:       t.Age,
+		Hunger:    t.Hunger,
+		Happiness: t.Happiness,
+		Health:    t.Health,
+		Energy:    t.Energy,
+		Weight:    t.Weight,
+		Stage:     t.Stage,
+		Form:      t.Form,
+		Created:   t.Created,
+		LastFed:   t.LastFed,
+		LastPlay:  t.LastPlay,
+		LastSleep: t.LastSleep,
+		IsAlive:   t.IsAlive,
+		Coins:     t.Coins,
+		Inventory: t.Inventory,
+		Equipped:  t.Equipped,
+		Skills:    skillsToConfig(t.Skills),
+
+		Genes:      config.GenesConfig{Color: t.Genes.Color, Traits: t.Genes.Traits},
+		Parents:    t.Parents,
+		Generation: t.Generation,
+		LastBred:   t.LastBred,
+
+		Peak: config.PeakConfig{
+			Happiness: t.Peak.Happiness,
+			Health:    t.Peak.Health,
+			Energy:    t.Peak.Energy,
+			Weight:    t.Peak.Weight,
+			Coins:     t.Peak.Coins,
+		},
+
+		Care:   careToConfig(t.Care),
+		Quests: questsToConfig(t.Quests),
+
+		Celebrated: t.Celebrated,
+
+		Balance:  t.Balance,
+		Hardcore: t.Hardcore,
+		Tampered: t.Tampered,
+		Sick:     t.Sick,
+
+		StreakReset: t.StreakReset,
+	}
+	return cfg
 }
 
 func (a *App) Run() {
@@ -120,20 +370,35 @@ func (a *App) Run() {
 
 	app := a.TApp.SetRoot(a.TLayout, true).EnableMouse(true)
 
+	// The first launch of the day opens with yesterday's report, after
+	// the welcome back summary of a long absence.
+	last := a.Config.App.LastLogin
+	careReport := !last.IsZero() && last.Before(startOfDay(a.now()))
+	switch {
+	case a.welcomeBack != "":
+		a.showWelcomeBack(careReport)
+	case careReport:
+		a.showCareReport()
+	}
+
+	// A client's alerts and webhooks come from the daemon.
+	if a.remote == nil {
+		go a.watchPets()
+		go a.runWebhooks()
+	}
+
 	defer func() {
 		a.uiMu.Lock()
 		a.tuiRunning = false
 		a.uiMu.Unlock()
 
-		now := time.Now()
-		a.Config.App.LastLogin = now
-		a.Config.App.CurrentLogin = now
-
-		a.updateConfigFromState()
-
-		if err := config.SaveConfig(a.Config); err != nil {
-			log.Printf("failed to save config: %v", err)
+		if a.remote != nil {
+			// The daemon owns the save.
+			a.remote.Close()
+			return
 		}
+		a.save()
+		a.closeJournal()
 	}()
 
 	if err := app.Run(); err != nil {
@@ -141,10 +406,47 @@ func (a *App) Run() {
 	}
 }
 
+// save writes the game and its stat history to disk.
+func (a *App) save() {
+	a.updateConfigFromState()
+
+	a.configMu.Lock()
+	now := a.now()
+	a.Config.App.LastLogin = now
+	a.Config.App.CurrentLogin = now
+	if err := config.SaveConfig(a.Config); err != nil {
+		log.Printf("failed to save config: %v", err)
+	}
+	a.configMu.Unlock()
+
+	a.stateMu.RLock()
+	stats := a.statsToConfigLocked()
+	a.stateMu.RUnlock()
+	if err := config.SaveStats(stats); err != nil {
+		log.Printf("failed to save stat history: %v", err)
+	}
+}
+
+func (a *App) closeJournal() {
+	if a.journal != nil {
+		if err := a.journal.Close(); err != nil {
+			log.Printf("failed to close event journal: %v", err)
+		}
+	}
+}
+
 func (a *App) showRestartModal() {
 	if a.modal != nil {
 		return // Modal already showing
 	}
+	if a.refuseAsClient("RESTART") {
+		return
+	}
+
+	if t, ok := a.tamagotchiSnapshot(); ok && t.Hardcore && t.IsAlive {
+		a.showPermadeathModal(t.Name)
+		return
+	}
 
 	modal := tview.NewModal().
 		SetText("Are you sure you want to restart?\n\nThis will reset your tamagotchi to a new egg.\nAll progress will be lost!").
@@ -162,16 +464,33 @@ func (a *App) showRestartModal() {
 }
 
 func (a *App) restartTamagotchi() {
-	newName := randomName()
-	newTamagotchi := newDefaultTamagotchi(newName)
+	a.stateMu.RLock()
+	newName := a.uniqueNameLocked()
+	a.stateMu.RUnlock()
+	newTamagotchi := newDefaultTamagotchi(newName, a.newPetBalance(), a.now())
 
 	a.stateMu.Lock()
+	oldName := ""
+	for i, pet := range a.pets {
+		if pet == a.currentTamagotchi {
+			oldName = pet.Name
+			cause := causeReplaced
+			if !pet.IsAlive {
+				cause = causeOfDeath(pet)
+			}
+			a.archivePetLocked(pet, cause, a.now())
+			a.pets[i] = newTamagotchi
+		}
+	}
+	if oldName == "" {
+		a.pets = append(a.pets, newTamagotchi)
+	}
 	a.currentTamagotchi = newTamagotchi
-	a.timeAccumulator = 0
+	a.recordLineageLocked(newTamagotchi)
 	a.stateMu.Unlock()
 
 	a.eventsMu.Lock()
-	a.gameEvents = make([]GameEvent, 0)
+	delete(a.gameEvents, oldName)
 	a.eventsMu.Unlock()
 
 	a.addGameEvent("RESTART", fmt.Sprintf("Started a new tamagotchi named %s! 🥚", newName))
@@ -182,14 +501,19 @@ func (a *App) gameLoop() {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	lastTick := time.Now()
-	for now := range ticker.C {
+	lastTick := a.now()
+	for range ticker.C {
+		now := a.now()
 		elapsed := now.Sub(lastTick)
+		a.checkClockJump(lastTick, now)
 		lastTick = now
 		if elapsed < 0 {
 			elapsed = 0
 		}
 		a.advanceTime(elapsed)
+		if a.scoreCareDays(now) && a.tuiIsRunning() {
+			a.TApp.QueueUpdateDraw(a.showCareReport)
+		}
 		a.refreshUI()
 	}
 }
@@ -202,14 +526,24 @@ func (a *App) advanceTime(elapsed time.Duration) bool {
 	var ticks int
 
 	a.stateMu.Lock()
-	if a.currentTamagotchi != nil {
+	if len(a.pets) > 0 {
+		interval := a.tickIntervalLocked()
 		a.timeAccumulator += elapsed
-		ticks = int(a.timeAccumulator / a.updateInterval)
+		ticks = int(a.timeAccumulator / interval)
 		if ticks > 0 {
-			a.timeAccumulator -= time.Duration(ticks) * a.updateInterval
-			for i := 0; i < ticks; i++ {
-				a.applyTickLocked()
-				if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+			a.timeAccumulator -= time.Duration(ticks) * interval
+			// Ticks are stamped as if they had happened on schedule, the
+			// last one now, so offline catch-up keeps its timeline. Quiet
+			// stretches are fast-forwarded, see catchup.go.
+			end := a.now()
+			for i := 0; i < ticks; {
+				n := a.fastForwardLocked(end, interval, i, ticks)
+				if n == 0 {
+					a.applyTickLocked(end.Add(-time.Duration(ticks-1-i)*interval), interval)
+					n = 1
+				}
+				i += n
+				if !a.anyAliveLocked() {
 					break
 				}
 			}
@@ -225,63 +559,136 @@ func (a *App) advanceTime(elapsed time.Duration) bool {
 	return false
 }
 
-func (a *App) applyTickLocked() {
-	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
-		return
+// applyTickLocked advances the game by one tick of the given length ending at
+// the given time. Each pet ticks at the pace of its own balance.
+func (a *App) applyTickLocked(at time.Time, interval time.Duration) {
+	a.vacationTickLocked(at)
+	paused := a.vacationPausesLocked(at)
+	if paused && !a.nextWorldEvent.IsZero() && !a.nextWorldEvent.After(at) {
+		// Nothing happens to pets away on vacation, world events included.
+		a.scheduleWorldEventLocked(at)
 	}
 
-	t := a.currentTamagotchi
-
-	t.Hunger = min(100, t.Hunger+5)
-
-	if t.Hunger > 80 {
-		t.Happiness = max(0, t.Happiness-2)
+	for _, t := range a.pets {
+		if !t.tickDue(interval) || (paused && !t.Hardcore) {
+			continue
+		}
+		a.tickPetLocked(t, at)
+		a.sitterTickLocked(t, at)
 	}
+	if !paused {
+		a.worldEventTickLocked(at)
+	}
+}
 
-	t.Energy = max(0, t.Energy-3)
-
-	if t.Hunger > 90 || t.Happiness < 10 {
-		t.Health = max(0, t.Health-1)
+func (a *App) tickPetLocked(t *Tamagotchi, at time.Time) {
+	if t == nil || !t.IsAlive {
+		return
 	}
 
+	health := t.Health
+	t.Hunger, t.Happiness, t.Energy, t.Health = t.decayed(t.toyComfort())
+	t.decaySkills(at)
+	t.recordPeaks()
+	a.illnessTickLocked(t, health, at)
+
 	if t.Health <= 0 {
 		if t.IsAlive {
 			t.IsAlive = false
-			a.addGameEvent("DEATH", "Your tamagotchi has passed away... 💔")
+			cause := causeOfDeath(t)
+			a.addPetEventAt(t.Name, "DEATH", fmt.Sprintf("%s has passed away from %s... 💔", t.Name, cause), at)
+			a.archivePetLocked(t, cause, at)
 		}
+		a.recordStatsLocked(t, at)
+		a.observeTickLocked(t, at)
 		return
 	}
 
-	ageInHours := int(time.Since(t.Created).Hours())
-	if ageInHours < 0 {
-		ageInHours = 0
-	}
-	t.Age = ageInHours / 24
+	a.recordStatsLocked(t, at)
+
+	defer a.observeTickLocked(t, at)
+	a.questTickLocked(t, at)
+	a.holidayTickLocked(t, at)
+
+	t.Age = t.ageOn(at)
 
 	oldStage := t.Stage
-	a.updateStageLocked(oldStage)
+	a.updateStageLocked(t, oldStage, at)
 }
 
-func (a *App) updateStageLocked(previousStage string) {
-	if a.currentTamagotchi == nil {
-		return
+// decayed returns the pet's needs after one tick of its balance, given the
+// happiness its toys give each tick.
+func (t *Tamagotchi) decayed(comfort int) (hunger, happiness, energy, health int) {
+	b := t.Balance
+	hunger = min(100, t.Hunger+b.HungerPerTick)
+
+	happiness = t.Happiness
+	if hunger > b.HungryAt {
+		happiness = max(0, happiness-b.SadnessPerTick)
+	}
+	if comfort > 0 && happiness < toyComfortCap {
+		happiness = min(toyComfortCap, happiness+comfort)
+	}
+
+	energy = max(0, t.Energy-b.EnergyPerTick)
+
+	health = t.Health
+	if hunger > b.StarvingAt || happiness < b.MiserableAt {
+		health = max(0, health-b.SicknessPerTick)
+	}
+	return hunger, happiness, energy, health
+}
+
+// illnessTickLocked logs the pet falling ill when it starts losing health
+// and, before it gets any worse, becoming gravely ill.
+func (a *App) illnessTickLocked(t *Tamagotchi, before int, at time.Time) {
+	losing := t.Health < before
+	if losing && !t.Sick {
+		a.addPetEventAt(t.Name, "SICK", fmt.Sprintf("%s is falling ill from %s. 🤒", t.Name, causeOfDeath(t)), at)
+	}
+	t.Sick = losing
+
+	if t.Health > 0 && before > criticalHealth && t.Health <= criticalHealth {
+		a.addPetEventAt(t.Name, "SICK", fmt.Sprintf(gravelyIll+" Health is down to %d. 🚑", t.Name, t.Health), at)
+	}
+}
+
+// ageOn returns the pet's age in whole days at the given time.
+func (t *Tamagotchi) ageOn(at time.Time) int {
+	ageInHours := int(at.Sub(t.Created).Hours())
+	if ageInHours < 0 {
+		ageInHours = 0
 	}
+	return ageInHours / 24
+}
 
+func stageForAge(age int) string {
 	switch {
-	case a.currentTamagotchi.Age < 1:
-		a.currentTamagotchi.Stage = "egg"
-	case a.currentTamagotchi.Age < 3:
-		a.currentTamagotchi.Stage = "baby"
-	case a.currentTamagotchi.Age < 7:
-		a.currentTamagotchi.Stage = "child"
-	case a.currentTamagotchi.Age < 14:
-		a.currentTamagotchi.Stage = "teen"
+	case age < 1:
+		return "egg"
+	case age < 3:
+		return "baby"
+	case age < 7:
+		return "child"
+	case age < 14:
+		return "teen"
 	default:
-		a.currentTamagotchi.Stage = "adult"
+		return "adult"
 	}
+}
 
-	if previousStage != a.currentTamagotchi.Stage {
-		a.addGameEvent("EVOLUTION", fmt.Sprintf("Your tamagotchi evolved to %s! 🎉", a.currentTamagotchi.Stage))
+func (a *App) updateStageLocked(t *Tamagotchi, previousStage string, at time.Time) {
+	t.Stage = stageForAge(t.Age)
+
+	if previousStage != t.Stage {
+		stage := t.Stage
+		if stage == "adult" {
+			t.Form = t.adultForm()
+			if t.Form != "" {
+				stage = fmt.Sprintf("%s (%s)", stage, t.Form)
+			}
+		}
+		a.addPetEventAt(t.Name, "EVOLUTION", fmt.Sprintf("%s evolved to %s! 🎉", t.Name, stage), at)
 	}
 }
 
@@ -295,9 +702,42 @@ func (a *App) applyOfflineProgress() {
 		return
 	}
 
-	if a.advanceTime(elapsed) {
-		a.addGameEvent("PROGRESS", fmt.Sprintf("Time passed while you were away: %s.", formatDuration(elapsed)))
+	before := a.petsSnapshot()
+
+	a.stateMu.Lock()
+	a.catchingUp = true
+	a.sitterOutOfFood = make(map[string]bool)
+	a.stateMu.Unlock()
+
+	a.eventsMu.Lock()
+	a.recordingOffline = true
+	a.eventsMu.Unlock()
+
+	advanced := a.advanceTime(elapsed)
+
+	a.eventsMu.Lock()
+	a.recordingOffline = false
+	events := a.offlineEvents
+	a.offlineEvents = nil
+	a.eventsMu.Unlock()
+
+	a.stateMu.Lock()
+	a.catchingUp = false
+	pet := ""
+	if a.currentTamagotchi != nil {
+		pet = a.currentTamagotchi.Name
+	}
+	a.stateMu.Unlock()
+
+	if advanced {
+		for _, t := range a.petsSnapshot() {
+			a.addPetEvent(t.Name, "PROGRESS", fmt.Sprintf("Time passed while you were away: %s.", formatDuration(elapsed)))
+		}
+		a.summarizeOfflineWorldEvents(pet)
 		a.updateConfigFromState()
+		if elapsed >= welcomeBackAfter {
+			a.welcomeBack = welcomeBackReport(elapsed, before, a.petsSnapshot(), events)
+		}
 	}
 }
 
@@ -338,34 +778,90 @@ func (a *App) refreshUI() {
 		if list := a.viewsList["sleep"]; list != nil {
 			a.generateSleepList(list)
 		}
+		if list := a.viewsList["shop"]; list != nil {
+			a.generateShopList(list)
+		}
+		if list := a.viewsList["items"]; list != nil {
+			a.generateItemsList(list)
+		}
+		if list := a.viewsList["pets"]; list != nil {
+			a.generatePetsList(list)
+		}
+		if list := a.viewsList["family"]; list != nil {
+			a.generateFamilyList(list)
+		}
+		if list := a.viewsList["memorial"]; list != nil {
+			a.generateMemorialList(list)
+		}
+		if list := a.viewsList["charts"]; list != nil {
+			a.generateChartsList(list)
+		}
+		if list := a.viewsList["achievements"]; list != nil {
+			a.generateAchievementsList(list)
+		}
+		if list := a.viewsList["vacation"]; list != nil {
+			a.generateVacationList(list)
+		}
 		if list := a.viewsList["events"]; list != nil {
 			a.generateEventsList(list)
 		}
+		a.showAchievementModal()
+		a.showWorldEventModal()
 	})
 }
 
 func (a *App) updateConfigFromState() {
+	s := a.stateSnapshot()
+
+	a.configMu.Lock()
+	a.Config.Pets = s.Pets
+	a.Config.Lineage = s.Lineage
+	a.Config.App.ActivePet = s.Active
+	a.Config.App.NextWorldEvent = s.NextWorldEvent
+	a.Config.App.Vacation = s.Vacation
+	a.Config.App.Sitter = s.Sitter
+	a.Config.App.Notify = s.Notify
+	a.Config.App.Webhooks = s.Webhooks
+	a.Config.Achievements = s.Achievements
+	a.configMu.Unlock()
+}
+
+// gameState is the part of the save the game keeps changing.
+type gameState struct {
+	Pets           []config.TamagotchiConfig `json:"pets"`
+	Active         string                    `json:"active"`
+	Lineage        []config.LineageConfig    `json:"lineage"`
+	NextWorldEvent time.Time                 `json:"next_world_event"`
+	Vacation       config.VacationConfig     `json:"vacation"`
+	Sitter         config.SitterConfig       `json:"sitter"`
+	Notify         config.NotifyConfig       `json:"notify"`
+	Webhooks       []config.WebhookConfig    `json:"webhooks"`
+	Achievements   config.AchievementsConfig `json:"achievements"`
+}
+
+func (a *App) stateSnapshot() gameState {
 	a.stateMu.RLock()
-	if a.currentTamagotchi == nil {
-		a.stateMu.RUnlock()
-		return
+	s := gameState{
+		Pets:           make([]config.TamagotchiConfig, 0, len(a.pets)),
+		Lineage:        lineageToConfig(a.lineage),
+		NextWorldEvent: a.nextWorldEvent,
+		Vacation:       a.vacation,
+		Sitter:         a.sitter,
+		Notify:         a.notify,
+		Webhooks:       slices.Clone(a.webhooks),
+	}
+	for _, t := range a.pets {
+		s.Pets = append(s.Pets, tamagotchiToConfig(t.clone()))
 	}
-	t := *a.currentTamagotchi
+	if a.currentTamagotchi != nil {
+		s.Active = a.currentTamagotchi.Name
+	}
+	s.Vacation.Taken = append([]time.Time(nil), a.vacation.Taken...)
+	s.Notify.Backends = append([]string(nil), a.notify.Backends...)
 	a.stateMu.RUnlock()
 
-	a.Config.Tamagotchi.Name = t.Name
-	a.Config.Tamagotchi.Age = t.Age
-	a.Config.Tamagotchi.Hunger = t.Hunger
-	a.Config.Tamagotchi.Happiness = t.Happiness
-	a.Config.Tamagotchi.Health = t.Health
-	a.Config.Tamagotchi.Energy = t.Energy
-	a.Config.Tamagotchi.Weight = t.Weight
-	a.Config.Tamagotchi.Stage = t.Stage
-	a.Config.Tamagotchi.Created = t.Created
-	a.Config.Tamagotchi.LastFed = t.LastFed
-	a.Config.Tamagotchi.LastPlay = t.LastPlay
-	a.Config.Tamagotchi.LastSleep = t.LastSleep
-	a.Config.Tamagotchi.IsAlive = t.IsAlive
+	s.Achievements = a.achievementsToConfig()
+	return s
 }
 
 func (a *App) tamagotchiSnapshot() (Tamagotchi, bool) {
@@ -376,25 +872,54 @@ func (a *App) tamagotchiSnapshot() (Tamagotchi, bool) {
 		return Tamagotchi{}, false
 	}
 
-	return *a.currentTamagotchi, true
+	t := a.currentTamagotchi.clone()
+	return t, true
 }
 
+// eventsSnapshot returns the event log of the current tamagotchi.
 func (a *App) eventsSnapshot() []GameEvent {
+	t, ok := a.tamagotchiSnapshot()
+	if !ok {
+		return nil
+	}
+
 	a.eventsMu.Lock()
 	defer a.eventsMu.Unlock()
 
-	if len(a.gameEvents) == 0 {
+	petEvents := a.gameEvents[t.Name]
+	if len(petEvents) == 0 {
 		return nil
 	}
 
-	events := make([]GameEvent, len(a.gameEvents))
-	copy(events, a.gameEvents)
+	events := make([]GameEvent, len(petEvents))
+	copy(events, petEvents)
 	return events
 }
 
-func newDefaultTamagotchi(name string) *Tamagotchi {
-	now := time.Now()
-	return &Tamagotchi{
+// clone returns a copy of the tamagotchi that shares no maps with it, so it
+// can be read without holding stateMu.
+func (t *Tamagotchi) clone() Tamagotchi {
+	c := *t
+	c.Inventory = copyInventory(t.Inventory)
+	c.Equipped = copyEquipped(t.Equipped)
+	c.Skills = copySkills(t.Skills)
+	c.Genes.Traits = append([]string(nil), t.Genes.Traits...)
+	c.Parents = append([]string(nil), t.Parents...)
+	c.Care = append([]CareDay(nil), t.Care...)
+	c.Quests = copyQuests(t.Quests)
+	c.Celebrated = copyCelebrated(t.Celebrated)
+	return c
+}
+
+func newDefaultTamagotchi(name string, balance config.BalanceConfig, now time.Time) *Tamagotchi {
+	return newTamagotchi(name, randomGenes(), balance, now)
+}
+
+// newTamagotchi hatches a fresh egg with the given genes and balance at the
+// given time.
+func newTamagotchi(name string, genes Genes, balance config.BalanceConfig, now time.Time) *Tamagotchi {
+	t := &Tamagotchi{
+		ID:        newPetID(),
 		Name:      name,
 		Age:       0,
 		Hunger:    50,
@@ -408,7 +933,23 @@ func newDefaultTamagotchi(name string) *Tamagotchi {
 		LastPlay:  now,
 		LastSleep: now,
 		IsAlive:   true,
+		Coins:     starterCoins,
+		Inventory: starterInventory(),
+		Genes:     genes,
+		Balance:   balance,
+		Hardcore:  balance.Preset == difficultyHardcore,
+	}
+	t.applyTraits(now)
+	t.recordPeaks()
+	return t
+}
+
+// now is the current time by the app's clock.
+func (a *App) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
 	}
+	return a.clock()
 }
 
 func formatDuration(d time.Duration) string {
@@ -434,24 +975,63 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%ds", seconds)
 }
 
+// addGameEvent logs an event for the current tamagotchi. It must not be
+// called while holding stateMu; use addPetEvent there instead.
 func (a *App) addGameEvent(eventType, message string) {
+	a.stateMu.RLock()
+	pet := ""
+	if a.currentTamagotchi != nil {
+		pet = a.currentTamagotchi.Name
+	}
+	a.stateMu.RUnlock()
+
+	a.addPetEvent(pet, eventType, message)
+}
+
+func (a *App) addPetEvent(pet, eventType, message string) {
+	a.addPetEventAt(pet, eventType, message, a.now())
+}
+
+// addPetEventAt logs an event that happened at a given time, such as during
+// offline catch-up.
+func (a *App) addPetEventAt(pet, eventType, message string, at time.Time) {
 	event := GameEvent{
+		Pet:       pet,
 		Type:      eventType,
 		Message:   message,
-		Timestamp: time.Now(),
+		Timestamp: at,
 	}
 
 	a.eventsMu.Lock()
-	a.gameEvents = append(a.gameEvents, event)
-
-	if len(a.gameEvents) > 50 {
-		a.gameEvents = a.gameEvents[len(a.gameEvents)-50:]
+	a.pushEventLocked(event)
+	if a.recordingOffline {
+		a.offlineEvents = append(a.offlineEvents, event)
 	}
+	a.publishLocked(event)
 	a.eventsMu.Unlock()
 
+	a.webhookInbox.add(event)
+	a.writeJournal(event)
 	a.requestRefresh()
 }
 
+// pushEventLocked adds an event to its pet's in-memory log, which only keeps
+// the most recent ones for display. The full history lives in the journal.
+func (a *App) pushEventLocked(event GameEvent) {
+	events := append(a.gameEvents[event.Pet], event)
+
+	if len(events) > maxRecentEvents {
+		events = events[len(events)-maxRecentEvents:]
+	}
+	a.gameEvents[event.Pet] = events
+}
+
+func (a *App) tuiIsRunning() bool {
+	a.uiMu.Lock()
+	defer a.uiMu.Unlock()
+	return a.tuiRunning
+}
+
 func (a *App) markUIReady() {
 	a.uiReadyOnce.Do(func() {
 		a.uiMu.Lock()
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// testClock is a clock tests can set.
+type testClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func newTestClock(t time.Time) *testClock {
+	return &testClock{t: t}
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+// newTestApp returns the game over a new save in a temporary config
+// directory, without the TUI or the game loop.
+func newTestApp(t *testing.T, clock *testClock) *App {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newApp(cfg, clock.now)
+	a.initializeStateFromConfig()
+	t.Cleanup(a.closeJournal)
+	return a
+}
+
+// setCurrentPet changes the current pet for a test.
+func setCurrentPet(a *App, change func(t *Tamagotchi)) string {
+	a.stateMu.Lock()
+	defer a.stateMu.Unlock()
+	change(a.currentTamagotchi)
+	return a.currentTamagotchi.Name
+}
+
+func TestPetFlagDoesNotReuseAPastPetsName(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.App.ActivePet = "Rex"
+	cfg.Lineage = []config.LineageConfig{{ID: "old", Name: "Rex", Generation: 1}}
+
+	clock := newTestClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	a := newApp(cfg, clock.now)
+	a.initializeStateFromConfig()
+	t.Cleanup(a.closeJournal)
+
+	for _, p := range a.petsSnapshot() {
+		if p.Name == "Rex" {
+			t.Fatalf("adopted a second pet named Rex")
+		}
+	}
+}
+
+func TestImportIntoEmptySave(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	clock := newTestClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	pet := newDefaultTamagotchi("Biscuit", balancePresets[difficultyClassic], clock.now().Add(-72*time.Hour))
+	pet.Hunger, pet.Coins, pet.Tampered = 30, 321, true
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AddImportedPet(cfg, tamagotchiToConfig(*pet), clock.now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SignatureCheck != config.SignatureValid {
+		t.Errorf("the imported save's signature is %s", cfg.SignatureCheck)
+	}
+	a := newApp(cfg, clock.now)
+	a.initializeStateFromConfig()
+	t.Cleanup(a.closeJournal)
+
+	pets := a.petsSnapshot()
+	if len(pets) != 1 || pets[0].ID != pet.ID || pets[0].Coins != 321 || !pets[0].Tampered {
+		t.Fatalf("the game started with %+v, want the imported %s", pets, pet.Name)
+	}
+}
+
+func TestImportIntoFullHouse(t *testing.T) {
+	cfg := &config.Config{}
+	for i := 0; i < maxPets; i++ {
+		cfg.Pets = append(cfg.Pets, config.TamagotchiConfig{ID: fmt.Sprintf("pet-%d", i), Name: fmt.Sprintf("Pet%d", i)})
+	}
+	err := AddImportedPet(cfg, config.TamagotchiConfig{ID: "new", Name: "Newcomer"}, time.Now())
+	if err == nil || len(cfg.Pets) != maxPets {
+		t.Fatalf("imported into a full house: %v", err)
+	}
+}
+
+func TestCheckDifficulty(t *testing.T) {
+	for _, name := range difficulties {
+		if err := CheckDifficulty(name); err != nil {
+			t.Errorf("CheckDifficulty(%q) = %v", name, err)
+		}
+	}
+	if err := CheckDifficulty("nightmare"); err == nil {
+		t.Error("CheckDifficulty accepted an unknown difficulty")
+	}
+}
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+const (
+	difficultyRelaxed  = "relaxed"
+	difficultyClassic  = "classic"
+	difficultyHardcore = "hardcore"
+)
+
+// difficulties lists the balance presets from easiest to hardest.
+var difficulties = []string{difficultyRelaxed, difficultyClassic, difficultyHardcore}
+
+// CheckDifficulty reports whether name is one of the balance presets.
+func CheckDifficulty(name string) error {
+	if _, ok := balancePresets[name]; !ok {
+		return fmt.Errorf("unknown difficulty %q (choose %s)", name, strings.Join(difficulties, ", "))
+	}
+	return nil
+}
+
+var balancePresets = map[string]config.BalanceConfig{
+	difficultyRelaxed: {
+		Preset:          difficultyRelaxed,
+		UpdateInterval:  60 * time.Second,
+		HungerPerTick:   3,
+		EnergyPerTick:   2,
+		SadnessPerTick:  1,
+		SicknessPerTick: 1,
+		HungryAt:        85,
+		StarvingAt:      95,
+		MiserableAt:     5,
+	},
+	difficultyClassic: {
+		Preset:          difficultyClassic,
+		UpdateInterval:  30 * time.Second,
+		HungerPerTick:   5,
+		EnergyPerTick:   3,
+		SadnessPerTick:  2,
+		SicknessPerTick: 1,
+		HungryAt:        80,
+		StarvingAt:      90,
+		MiserableAt:     10,
+	},
+	difficultyHardcore: {
+		Preset:          difficultyHardcore,
+		UpdateInterval:  20 * time.Second,
+		HungerPerTick:   6,
+		EnergyPerTick:   4,
+		SadnessPerTick:  3,
+		SicknessPerTick: 2,
+		HungryAt:        75,
+		StarvingAt:      85,
+		MiserableAt:     15,
+	},
+}
+
+// newPetBalance is the balance new pets are created with: the difficulty
+// preset of the save with its custom overrides applied.
+func (a *App) newPetBalance() config.BalanceConfig {
+	difficulty := a.Config.App.Difficulty
+	if difficulty == "" {
+		difficulty = difficultyClassic
+	}
+	b, ok := balancePresets[difficulty]
+	if !ok {
+		log.Printf("unknown difficulty %q, using %s", difficulty, difficultyClassic)
+		b = balancePresets[difficultyClassic]
+	}
+	if difficulty == difficultyHardcore {
+		return b // hardcore runs all play by the same rules
+	}
+	return applyBalanceOverrides(b, a.Config.App.Balance)
+}
+
+// applyBalanceOverrides changes the preset by the overrides that make sense,
+// logging the ones that don't.
+func applyBalanceOverrides(b config.BalanceConfig, o config.BalanceOverrides) config.BalanceConfig {
+	if o.UpdateInterval != nil {
+		if *o.UpdateInterval < time.Second {
+			log.Printf("ignoring balance update_interval %s: it must be at least 1s", *o.UpdateInterval)
+		} else {
+			b.UpdateInterval = *o.UpdateInterval
+			b.Custom = true
+		}
+	}
+
+	stats := []struct {
+		name  string
+		value *int
+		field *int
+	}{
+		{"hunger_per_tick", o.HungerPerTick, &b.HungerPerTick},
+		{"energy_per_tick", o.EnergyPerTick, &b.EnergyPerTick},
+		{"sadness_per_tick", o.SadnessPerTick, &b.SadnessPerTick},
+		{"sickness_per_tick", o.SicknessPerTick, &b.SicknessPerTick},
+		{"hungry_at", o.HungryAt, &b.HungryAt},
+		{"starving_at", o.StarvingAt, &b.StarvingAt},
+		{"miserable_at", o.MiserableAt, &b.MiserableAt},
+	}
+	for _, s := range stats {
+		if s.value == nil {
+			continue
+		}
+		if *s.value < 0 || *s.value > 100 {
+			log.Printf("ignoring balance %s %d: it must be between 0 and 100", s.name, *s.value)
+			continue
+		}
+		*s.field = *s.value
+		b.Custom = true
+	}
+	return b
+}
+
+// balanceOrClassic gives pets from before balance profiles existed the
+// classic preset, which is what they were playing.
+func balanceOrClassic(b config.BalanceConfig) config.BalanceConfig {
+	if b.Preset == "" || b.UpdateInterval <= 0 {
+		return balancePresets[difficultyClassic]
+	}
+	return b
+}
+
+func balanceName(b config.BalanceConfig) string {
+	if b.Custom {
+		return fmt.Sprintf("%s (custom)", b.Preset)
+	}
+	return b.Preset
+}
+
+// describeBalance sums up a balance in two lines.
+func describeBalance(b config.BalanceConfig) []string {
+	return []string{
+		fmt.Sprintf("Every %s: hunger +%d, energy -%d", b.UpdateInterval, b.HungerPerTick, b.EnergyPerTick),
+		fmt.Sprintf("Hunger above %d: happiness -%d; above %d or happiness below %d: health -%d",
+			b.HungryAt, b.SadnessPerTick, b.StarvingAt, b.MiserableAt, b.SicknessPerTick),
+	}
+}
+
+// tickIntervalLocked is how often the game ticks: as often as the fastest
+// pet of the roster needs. Slower pets skip ticks to keep their own pace.
+func (a *App) tickIntervalLocked() time.Duration {
+	interval := time.Duration(0)
+	for _, t := range a.pets {
+		if interval == 0 || t.Balance.UpdateInterval < interval {
+			interval = t.Balance.UpdateInterval
+		}
+	}
+	if interval <= 0 {
+		return defaultUpdateInterval
+	}
+	return interval
+}
+
+// tickDue counts a game tick of the given length towards the pet's own
+// interval and reports whether the pet should tick now.
+func (t *Tamagotchi) tickDue(tick time.Duration) bool {
+	t.tickDebt += tick
+	if t.tickDebt < t.Balance.UpdateInterval {
+		return false
+	}
+	t.tickDebt -= t.Balance.UpdateInterval
+	return true
+}
@@ -0,0 +1,418 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+const (
+	// careGoodScore is the daily score that keeps a streak going.
+	careGoodScore = 60
+
+	// careHistoryDays is how many daily scores each pet keeps.
+	careHistoryDays = 90
+
+	// Needs answered within careResponseGrace count fully; after
+	// careResponseLimit they count as missed.
+	careResponseGrace = 15 * time.Minute
+	careResponseLimit = 4 * time.Hour
+
+	// missedCarePenalty is taken off the score for every missed need.
+	missedCarePenalty = 5
+)
+
+// careEventTypes are the events that answer a pet's needs.
+var careEventTypes = map[string]bool{"FEED": true, "PLAY": true, "SLEEP": true, "HEAL": true}
+
+// CareDay is how well a pet was looked after over one calendar day.
+type CareDay struct {
+	Day      time.Time // local midnight
+	Score    int
+	Healthy  float64 // share of the day with stats in healthy ranges
+	Response time.Duration
+	Needs    int
+	Missed   int
+}
+
+// healthyShare is the part of a sample's stats that were in a healthy range.
+func healthyShare(s config.StatSample) float64 {
+	ok := 0
+	if s.Hunger <= 50 {
+		ok++
+	}
+	if s.Happiness >= 50 {
+		ok++
+	}
+	if s.Health >= 80 {
+		ok++
+	}
+	if s.Energy >= 30 {
+		ok++
+	}
+	return float64(ok) / 4
+}
+
+// needy reports whether a sample shows the pet asking for care.
+func needy(s config.StatSample) bool {
+	return s.Hunger >= 80 || s.Happiness <= 20 || s.Energy <= 20 || s.Health <= 50
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// scoreCareDay scores the day starting at day from the pet's stat samples
+// and care events, both oldest first. It reports false when nothing was
+// recorded that day.
+func scoreCareDay(day time.Time, samples []config.StatSample, events []GameEvent) (CareDay, bool) {
+	end := day.AddDate(0, 0, 1)
+	result := CareDay{Day: day}
+
+	var healthy, weight float64
+	var responseScore float64
+	var responseTotal time.Duration
+	answered := 0
+	wasNeedy := false
+	next := 0
+	for _, s := range samples {
+		if s.Time.Before(day) || !s.Time.Before(end) {
+			continue
+		}
+		w := float64(max(1, s.Count))
+		healthy += healthyShare(s) * w
+		weight += w
+
+		isNeedy := needy(s)
+		if isNeedy && !wasNeedy {
+			result.Needs++
+			for next < len(events) && events[next].Timestamp.Before(s.Time) {
+				next++
+			}
+			if next < len(events) && events[next].Timestamp.Sub(s.Time) <= careResponseLimit {
+				delay := events[next].Timestamp.Sub(s.Time)
+				responseTotal += delay
+				answered++
+				late := math.Max(0, float64(delay-careResponseGrace)) / float64(careResponseLimit-careResponseGrace)
+				responseScore += 1 - late
+			} else {
+				result.Missed++
+			}
+		}
+		wasNeedy = isNeedy
+	}
+	if weight == 0 {
+		return result, false
+	}
+
+	result.Healthy = healthy / weight
+	response := 1.0
+	if result.Needs > 0 {
+		response = responseScore / float64(result.Needs)
+	}
+	if answered > 0 {
+		result.Response = responseTotal / time.Duration(answered)
+	}
+
+	score := int(math.Round(100*(0.6*result.Healthy+0.4*response))) - missedCarePenalty*result.Missed
+	result.Score = max(0, min(100, score))
+	return result, true
+}
+
+// careStreak counts the consecutive good days ending with the last score,
+// starting no earlier than the day after since.
+func careStreak(days []CareDay, since time.Time) int {
+	streak := 0
+	for i := len(days) - 1; i >= 0; i-- {
+		if days[i].Score < careGoodScore || days[i].Day.Before(since) {
+			break
+		}
+		if i < len(days)-1 && !days[i].Day.AddDate(0, 0, 1).Equal(days[i+1].Day) {
+			break
+		}
+		streak++
+	}
+	return streak
+}
+
+// careOn returns the pet's score for the given day.
+func (t *Tamagotchi) careOn(day time.Time) (CareDay, bool) {
+	for i := len(t.Care) - 1; i >= 0; i-- {
+		if t.Care[i].Day.Equal(day) {
+			return t.Care[i], true
+		}
+	}
+	return CareDay{}, false
+}
+
+// between returns the samples from the finest tier that reaches back to
+// from, limited to [from, to).
+func (s statSeries) between(from, to time.Time) []config.StatSample {
+	tier := -1
+	for i := range s {
+		if len(s[i]) > 0 {
+			tier = i
+			if !s[i][0].Time.After(from) {
+				break
+			}
+		}
+	}
+	if tier < 0 {
+		return nil
+	}
+
+	var out []config.StatSample
+	for _, sample := range s[tier] {
+		if !sample.Time.Before(from) && sample.Time.Before(to) {
+			out = append(out, sample)
+		}
+	}
+	return out
+}
+
+// careEventsSince reads the care events of every pet from the journal, oldest
+// first, falling back to the recent events kept in memory.
+func (a *App) careEventsSince(since time.Time) map[string][]GameEvent {
+	out := make(map[string][]GameEvent)
+	keep := func(event GameEvent) {
+		if careEventTypes[event.Type] && !event.Timestamp.Before(since) {
+			out[event.Pet] = append(out[event.Pet], event)
+		}
+	}
+
+	// Only the files back to the first one that starts before since are read.
+	dir, err := config.Dir()
+	if err == nil {
+		err = config.ReadJournalNewestFirst(dir, func(records []config.EventRecord) bool {
+			for _, rec := range records {
+				keep(eventFromRecord(rec))
+			}
+			return len(records) == 0 || !records[0].Timestamp.Before(since)
+		})
+		if err != nil {
+			log.Printf("failed to read part of the event journal: %v", err)
+		}
+	} else {
+		log.Printf("failed to read event journal: %v", err)
+		a.eventsMu.Lock()
+		for _, events := range a.gameEvents {
+			for _, event := range events {
+				keep(event)
+			}
+		}
+		a.eventsMu.Unlock()
+	}
+
+	for _, events := range out {
+		sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp.Before(events[j].Timestamp) })
+	}
+	return out
+}
+
+// scoreCareDays scores every finished day that hasn't been scored yet, once
+// per calendar day. It reports whether yesterday got a new score.
+func (a *App) scoreCareDays(now time.Time) bool {
+	today := startOfDay(now)
+	if a.careCheckedDay.Equal(today) {
+		return false
+	}
+	a.careCheckedDay = today
+
+	// Work out which days are missing before reading the journal, which is
+	// only needed when there are some.
+	oldest := today
+	a.stateMu.RLock()
+	for _, t := range a.pets {
+		if first := a.firstUnscoredDayLocked(t, today); first.Before(oldest) {
+			oldest = first
+		}
+	}
+	a.stateMu.RUnlock()
+	if !oldest.Before(today) {
+		return false
+	}
+
+	events := a.careEventsSince(oldest)
+
+	yesterday := today.AddDate(0, 0, -1)
+	scoredYesterday := false
+
+	a.stateMu.Lock()
+	for _, t := range a.pets {
+		for day := a.firstUnscoredDayLocked(t, today); day.Before(today); day = day.AddDate(0, 0, 1) {
+			samples := a.stats[t.ID].between(day, day.AddDate(0, 0, 1))
+			result, ok := scoreCareDay(day, samples, events[t.Name])
+			if !ok {
+				continue
+			}
+			t.Care = append(t.Care, result)
+			if day.Equal(yesterday) {
+				scoredYesterday = true
+			}
+		}
+		if len(t.Care) > careHistoryDays {
+			t.Care = t.Care[len(t.Care)-careHistoryDays:]
+		}
+	}
+	a.stateMu.Unlock()
+
+	return scoredYesterday
+}
+
+func (a *App) firstUnscoredDayLocked(t *Tamagotchi, today time.Time) time.Time {
+	first := startOfDay(t.Created)
+	if n := len(t.Care); n > 0 {
+		first = t.Care[n-1].Day.AddDate(0, 0, 1)
+	}
+	if limit := today.AddDate(0, 0, -careHistoryDays); first.Before(limit) {
+		first = limit
+	}
+	return first
+}
+
+// careReport summarises how every pet, or just the named one, was looked
+// after on the given day.
+func (a *App) careReport(day time.Time, pet string) string {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Yesterday's report (%s)\n", day.Format("Mon 2006-01-02"))
+	for _, t := range a.pets {
+		if pet != "" && t.Name != pet {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n%s: ", t.Name)
+		result, ok := t.careOn(day)
+		if !ok {
+			b.WriteString("nothing recorded\n")
+			continue
+		}
+		b.WriteString(result.summary())
+		if streak := careStreak(t.Care, t.StreakReset); streak > 0 {
+			fmt.Fprintf(&b, "🔥 %d-day streak of good care\n", streak)
+		}
+	}
+	return b.String()
+}
+
+// showCareReport opens yesterday's report, if any pet has one.
+func (a *App) showCareReport() {
+	if a.modal != nil {
+		return // Modal already showing
+	}
+
+	yesterday := startOfDay(a.now()).AddDate(0, 0, -1)
+	scored := false
+	a.stateMu.RLock()
+	for _, t := range a.pets {
+		if _, ok := t.careOn(yesterday); ok {
+			scored = true
+		}
+	}
+	a.stateMu.RUnlock()
+	if !scored {
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(a.careReport(yesterday, "")).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.TApp.SetRoot(a.TLayout, true).SetFocus(a.TLayout)
+			a.modal = nil
+		})
+
+	a.modal = modal
+	a.TApp.SetRoot(modal, true).SetFocus(modal)
+}
+
+func (d CareDay) summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "care score %d/100 %s\n", d.Score, careGrade(d.Score))
+	fmt.Fprintf(&b, "Healthy %.0f%% of the day", d.Healthy*100)
+	switch {
+	case d.Needs == 0:
+		b.WriteString(", no needs recorded\n")
+	case d.Needs == d.Missed:
+		fmt.Fprintf(&b, ", %d needs all missed\n", d.Needs)
+	default:
+		fmt.Fprintf(&b, ", %d needs answered in %s on average, %d missed\n", d.Needs-d.Missed, formatDuration(d.Response), d.Missed)
+	}
+	return b.String()
+}
+
+func careGrade(score int) string {
+	switch {
+	case score >= 90:
+		return "🌟"
+	case score >= careGoodScore:
+		return "😊"
+	case score >= 30:
+		return "😐"
+	default:
+		return "😢"
+	}
+}
+
+// YesterdaysReport scores the pets in cfg without starting the game and
+// returns yesterday's report, for every pet or just the named one. Nothing is
+// saved; the game scores the same days again when it next starts.
+func YesterdaysReport(cfg *config.Config, pet string) (string, error) {
+	a := &App{Config: cfg, gameEvents: make(map[string][]GameEvent)}
+	for _, c := range cfg.Pets {
+		a.pets = append(a.pets, tamagotchiFromConfig(c))
+	}
+	if pet != "" && a.findPetLocked(pet) == nil {
+		return "", fmt.Errorf("no pet named %q", pet)
+	}
+
+	stats, err := config.LoadStats()
+	if err != nil {
+		return "", err
+	}
+	a.stats = statsFromConfig(stats)
+
+	now := a.now()
+	a.scoreCareDays(now)
+	return a.careReport(startOfDay(now).AddDate(0, 0, -1), pet), nil
+}
+
+func careFromConfig(days []config.CareDayConfig) []CareDay {
+	out := make([]CareDay, 0, len(days))
+	for _, d := range days {
+		out = append(out, CareDay{
+			Day: d.Day.In(time.Local), // days are local midnights
+
+			Score:    d.Score,
+			Healthy:  d.Healthy,
+			Response: d.Response,
+			Needs:    d.Needs,
+			Missed:   d.Missed,
+		})
+	}
+	return out
+}
+
+func careToConfig(days []CareDay) []config.CareDayConfig {
+	out := make([]config.CareDayConfig, 0, len(days))
+	for _, d := range days {
+		out = append(out, config.CareDayConfig{
+			Day:      d.Day,
+			Score:    d.Score,
+			Healthy:  d.Healthy,
+			Response: d.Response,
+			Needs:    d.Needs,
+			Missed:   d.Missed,
+		})
+	}
+	return out
+}
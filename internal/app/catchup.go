@@ -0,0 +1,505 @@
+package app
+
+import (
+	"math"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// Long absences are caught up in quiet stretches: runs of ticks in which no
+// pet does anything but decay, at a steady rate, so nothing but its needs,
+// skills, peaks and stat history can change. A stretch is played in one go:
+// each need moves by the same amount every tick until it crosses a threshold
+// (hunger getting hungry or starving, happiness getting miserable or reaching
+// what toys keep it at, a need hitting 0 or 100) or something else would
+// happen (falling ill, the sitter stepping in, a day passing, a hold quest's
+// window, a world event). Those are worked out directly, the stretch ends
+// just before the first of them and that tick is played in full, so the
+// outcome is the same as ticking one by one. Only the stat history is
+// coarser: a stretch adds one sample averaging its ticks.
+
+// needs are a pet's hunger, happiness, energy and health.
+type needs struct {
+	hunger, happiness, energy, health int
+}
+
+func (t *Tamagotchi) needs() needs {
+	return needs{t.Hunger, t.Happiness, t.Energy, t.Health}
+}
+
+func (t *Tamagotchi) setNeeds(n needs) {
+	t.Hunger, t.Happiness, t.Energy, t.Health = n.hunger, n.happiness, n.energy, n.health
+}
+
+// after returns the needs after n ticks changing them by d each.
+func (n needs) after(ticks int, d needs) needs {
+	return needs{
+		n.hunger + ticks*d.hunger,
+		n.happiness + ticks*d.happiness,
+		n.energy + ticks*d.energy,
+		n.health + ticks*d.health,
+	}
+}
+
+// keeps returns for how many steps i = 0, 1, ... whether x+i*d is above limit
+// stays as it is at the start, math.MaxInt if for good.
+func keeps(x, d int, above bool, limit int) int {
+	switch {
+	case (x > limit) != above:
+		return 0
+	case above && d < 0:
+		return (x-limit-1)/-d + 1
+	case !above && d > 0:
+		return (limit-x)/d + 1
+	default:
+		return math.MaxInt
+	}
+}
+
+// steadyDecay returns how the pet's needs change on its next tick, given the
+// happiness its toys give each tick, and for how many ticks from now they
+// keep changing by as much: until one of them crosses a threshold or a
+// bound that decayed goes by.
+func (t *Tamagotchi) steadyDecay(comfort int) (needs, int) {
+	b := t.Balance
+	now := t.needs()
+	var next needs
+	next.hunger, next.happiness, next.energy, next.health = t.decayed(comfort)
+	d := needs{next.hunger - now.hunger, next.happiness - now.happiness, next.energy - now.energy, next.health - now.health}
+
+	n := math.MaxInt
+	limit := func(m int) { n = min(n, m) }
+	// A need held at a bound stays there, one only just brought to it doesn't.
+	bounded := func(moved int) {
+		if moved != 0 {
+			limit(1)
+		}
+	}
+
+	if now.hunger+b.HungerPerTick > 100 {
+		bounded(d.hunger)
+	} else {
+		limit(keeps(now.hunger, d.hunger, false, 100-b.HungerPerTick))
+	}
+	hungry := next.hunger > b.HungryAt
+	limit(keeps(next.hunger, d.hunger, hungry, b.HungryAt))
+	limit(keeps(next.hunger, d.hunger, next.hunger > b.StarvingAt, b.StarvingAt))
+
+	switch felt := now.happiness; {
+	case hungry && felt-b.SadnessPerTick < 0:
+		bounded(d.happiness)
+	default:
+		if hungry {
+			felt -= b.SadnessPerTick
+			limit(keeps(felt, d.happiness, true, -1))
+		}
+		if comfort <= 0 {
+			break
+		}
+		comforted := felt < toyComfortCap
+		limit(keeps(felt, d.happiness, !comforted, toyComfortCap-1))
+		if comforted && felt+comfort > toyComfortCap {
+			bounded(d.happiness)
+		} else if comforted {
+			limit(keeps(felt+comfort, d.happiness, false, toyComfortCap))
+		}
+	}
+
+	if now.energy-b.EnergyPerTick < 0 {
+		bounded(d.energy)
+	} else {
+		limit(keeps(now.energy-b.EnergyPerTick, d.energy, true, -1))
+	}
+
+	miserable := next.happiness < b.MiserableAt
+	limit(keeps(next.happiness, d.happiness, !miserable, b.MiserableAt-1))
+	if next.hunger > b.StarvingAt || miserable {
+		if now.health-b.SicknessPerTick < 0 {
+			bounded(d.health)
+		} else {
+			limit(keeps(now.health-b.SicknessPerTick, d.health, true, -1))
+		}
+	}
+	return d, n
+}
+
+// pace is which ticks of a stretch a pet plays: those that bring its debt up
+// to its own interval, bar the ones a vacation pauses.
+type pace struct {
+	debt, tick, every time.Duration
+	frozen            bool // a frozen vacation: it plays none
+	slowFrom          int  // on a slow vacation, the first tick it may play, then every vacationSlowdown-th; -1 otherwise
+}
+
+func (p pace) due(i int) bool {
+	return (p.debt+time.Duration(i+1)*p.tick)/p.every > (p.debt+time.Duration(i)*p.tick)/p.every
+}
+
+// plays returns how many of the first k ticks the pet plays.
+func (p pace) plays(k int) int {
+	switch {
+	case p.frozen:
+		return 0
+	case p.slowFrom < 0:
+		return int((p.debt + time.Duration(k)*p.tick) / p.every)
+	case k <= p.slowFrom:
+		return 0
+	case p.tick == p.every:
+		return (k-p.slowFrom-1)/vacationSlowdown + 1
+	}
+	// Pets on a slower pace than the game's skip ticks of their own, which
+	// don't line up with the vacation's.
+	n := 0
+	for i := p.slowFrom; i < k; i += vacationSlowdown {
+		if p.due(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// nth returns the tick at which the pet plays for the j-th time, or k if that
+// isn't among the first k.
+func (p pace) nth(j, k int) int {
+	i := k
+	switch {
+	case p.frozen:
+	case p.slowFrom < 0:
+		i = int((time.Duration(j)*p.every-p.debt+p.tick-1)/p.tick) - 1
+	case p.tick == p.every:
+		i = p.slowFrom + (j-1)*vacationSlowdown
+	default:
+		for i = p.slowFrom; i < k; i += vacationSlowdown {
+			if p.due(i) {
+				if j--; j == 0 {
+					break
+				}
+			}
+		}
+	}
+	return min(i, k)
+}
+
+// quietPet is what a quiet stretch needs to know about a pet, worked out at
+// the first tick the pet plays in it.
+type quietPet struct {
+	until time.Time    // when the pet turns a day older or a hold quest's window opens or closes
+	holds []*questHold // hold quests being watched
+}
+
+// fastForwardLocked plays the quiet ticks among those numbered from first to
+// ticks-1, stamped as advanceTime stamps them, and returns how many it
+// played. It stops at the first tick that needs playing in full.
+func (a *App) fastForwardLocked(end time.Time, interval time.Duration, first, ticks int) int {
+	stop := ticks
+	if !a.anyAliveLocked() {
+		// advanceTime stops after the next tick, quiet or not.
+		stop = first + 1
+	}
+
+	start := end.Add(-time.Duration(ticks-1-first) * interval)
+	at := func(i int) time.Time { return start.Add(time.Duration(i) * interval) }
+	before := func(until time.Time) int {
+		if !until.After(start) {
+			return 0
+		}
+		return int((until.Sub(start) + interval - 1) / interval)
+	}
+	k := min(stop-first, before(a.quietUntilLocked(start)))
+
+	// The stretch is the same kind of vacation tick to tick, as it ends
+	// where one begins or ends.
+	paused := a.vacationPausesLocked(start)
+	slowFrom := -1
+	if onVacation(a.vacation, start) && a.vacation.Mode != vacationFreeze {
+		slowFrom = int((vacationSlowdown - start.UnixNano()/int64(interval)%vacationSlowdown) % vacationSlowdown)
+	}
+
+	paces := make([]pace, len(a.pets))
+	steps := make([]needs, len(a.pets))
+	observedFrom := k
+	for j, t := range a.pets {
+		p := pace{debt: t.tickDebt, tick: interval, every: t.Balance.UpdateInterval, slowFrom: -1}
+		if !t.Hardcore {
+			p.frozen = paused && slowFrom < 0
+			p.slowFrom = slowFrom
+		}
+		paces[j] = p
+		if !t.IsAlive || p.plays(k) == 0 {
+			continue
+		}
+
+		firstPlay := p.nth(1, k)
+		q, ok := a.quietPetLocked(t, at(firstPlay))
+		if !ok {
+			k = firstPlay
+			continue
+		}
+		k = min(k, before(q.until))
+		if grace := t.skillsFadeFrom(at(firstPlay)); !grace.IsZero() {
+			k = min(k, before(grace))
+		}
+
+		d, n := t.steadyDecay(t.toyComfort())
+		n = min(min(n, p.plays(k)), a.quietDecayLocked(t, d, at(firstPlay)))
+		n = holdsFor(t, d, n, q.holds)
+		steps[j] = d
+		if p.plays(k) > n {
+			k = p.nth(n+1, k)
+		}
+		if p.plays(k) > 0 {
+			observedFrom = min(observedFrom, firstPlay)
+		}
+	}
+
+	if observedFrom < k {
+		until, ok := a.quietAchievementsLocked(at(observedFrom))
+		if !ok {
+			k = observedFrom
+		} else if !until.IsZero() {
+			k = min(k, before(until))
+		}
+	}
+	if k <= 0 {
+		return 0
+	}
+
+	observedAt := -1
+	for j, t := range a.pets {
+		p := paces[j]
+		n := p.plays(k)
+		t.tickDebt = (p.debt + time.Duration(k)*p.tick) % p.every
+		if !t.IsAlive || n == 0 {
+			continue
+		}
+
+		last := p.nth(n, k)
+		observedAt = max(observedAt, last)
+		a.decayForLocked(t, steps[j], n, at(last))
+	}
+	if observedAt >= 0 {
+		a.achievementsMu.Lock()
+		a.achievements.observedAt = at(observedAt)
+		a.achievementsMu.Unlock()
+	}
+	return k
+}
+
+// decayForLocked plays n ticks of steady decay on the pet, the last at last.
+func (a *App) decayForLocked(t *Tamagotchi, d needs, n int, last time.Time) {
+	from := t.needs()
+	first, final := from.after(1, d), from.after(n, d)
+	t.setNeeds(final)
+
+	for name, s := range t.Skills {
+		if s.Level > 0 && last.Sub(s.LastTrained) >= skillGracePeriod {
+			s.Level = math.Max(0, s.Level-float64(n)*skillDecayPerTick)
+			t.Skills[name] = s
+		}
+	}
+
+	// The needs move one way, so they peak at one end of the stretch.
+	t.Peak.Happiness = max(t.Peak.Happiness, max(first.happiness, final.happiness))
+	t.Peak.Health = max(t.Peak.Health, max(first.health, final.health))
+	t.Peak.Energy = max(t.Peak.Energy, max(first.energy, final.energy))
+	t.recordPeaks()
+
+	// So does their average.
+	mean := func(from, d int) float64 { return float64(from) + float64(d)*float64(n+1)/2 }
+	if a.stats == nil {
+		a.stats = make(map[string]statSeries)
+	}
+	a.stats[t.ID] = a.stats[t.ID].addSample(config.StatSample{
+		Hunger:    mean(from.hunger, d.hunger),
+		Happiness: mean(from.happiness, d.happiness),
+		Health:    mean(from.health, d.health),
+		Energy:    mean(from.energy, d.energy),
+		Weight:    t.Weight,
+		Count:     n,
+	}, last)
+}
+
+// skillsFadeFrom returns when the next of the pet's skills starts fading
+// after at, or the zero time if none will.
+func (t *Tamagotchi) skillsFadeFrom(at time.Time) time.Time {
+	var from time.Time
+	for _, s := range t.Skills {
+		fades := s.LastTrained.Add(skillGracePeriod)
+		if s.Level > 0 && fades.After(at) && (from.IsZero() || fades.Before(from)) {
+			from = fades
+		}
+	}
+	return from
+}
+
+// quietUntilLocked returns when the stretch starting at start has to end
+// for reasons that don't depend on the pets: the end of the day, which
+// quests and holidays go by, the start or end of a vacation and the next
+// world event.
+func (a *App) quietUntilLocked(start time.Time) time.Time {
+	y, m, d := start.Date()
+	until := time.Date(y, m, d+1, 0, 0, 0, 0, start.Location())
+
+	if v := a.vacation; v.Mode != "" {
+		if v.Start.After(start) && v.Start.Before(until) {
+			until = v.Start
+		}
+		if v.Until.Before(until) {
+			until = v.Until
+		}
+	}
+	if len(a.worldEvents.Events) > 0 {
+		if a.nextWorldEvent.IsZero() {
+			return start // the first tick schedules one
+		}
+		if a.nextWorldEvent.Before(until) {
+			until = a.nextWorldEvent
+		}
+	}
+	return until
+}
+
+// quietPetLocked reports whether ticking the pet from at on only makes it
+// decay, and until when: its quests are those of the day and week and no
+// hold quest is about to be started or settled, it has celebrated the day's
+// holidays and it is neither older nor at a new stage.
+func (a *App) quietPetLocked(t *Tamagotchi, at time.Time) (quietPet, bool) {
+	y, m, d := at.Date()
+	hour := func(h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, at.Location()) }
+	q := quietPet{until: t.Created.Add(time.Duration(t.Age+1) * 24 * time.Hour)}
+	earlier := func(until time.Time) {
+		if until.Before(q.until) {
+			q.until = until
+		}
+	}
+
+	day, week := dailyPeriod(at), weeklyPeriod(at)
+	have := make(map[string]bool)
+	for _, quest := range t.Quests {
+		if quest.Period != day && quest.Period != week {
+			return q, false
+		}
+		have[quest.Period] = true
+
+		tmpl, ok := findQuestTemplate(quest.ID)
+		if !ok || tmpl.Hold == nil || !quest.Done.IsZero() || quest.Failed || quest.Period != day {
+			continue
+		}
+		switch h := tmpl.Hold; {
+		case at.Hour() < h.From:
+			earlier(hour(h.From))
+		case at.Hour() >= h.Until || (quest.Progress == 0 && at.Hour() == h.From):
+			return q, false
+		default:
+			q.holds = append(q.holds, h)
+			earlier(hour(h.Until))
+		}
+	}
+	if !have[day] || !have[week] {
+		return q, false
+	}
+
+	for _, h := range t.holidaysOn(at) {
+		if t.Celebrated[h.ID] != at.Year() {
+			return q, false
+		}
+	}
+
+	return q, t.ageOn(at) == t.Age && stageForAge(t.Age) == t.Stage
+}
+
+// quietDecayLocked returns for how many ticks of steady decay by d the pet
+// does nothing else: it doesn't die, fall ill, get better or gravely ill, the
+// sitter has nothing to do, having already said it ran out of food, and it
+// neither starts nor stops being healthy for the achievement.
+func (a *App) quietDecayLocked(t *Tamagotchi, d needs, at time.Time) int {
+	if (d.health < 0) != t.Sick {
+		return 0
+	}
+	next := t.needs().after(1, d)
+
+	n := keeps(next.health, d.health, true, 0)
+	if t.Health > criticalHealth {
+		n = min(n, keeps(next.health, d.health, true, criticalHealth))
+	}
+	if s := a.sitter; s.Enabled && a.catchingUp {
+		n = min(n, keeps(next.energy, d.energy, true, s.SleepAt))
+		if !a.sitterOutOfFood[t.ID] || a.sitterHasFood(t, at) {
+			n = min(n, keeps(next.hunger, d.hunger, false, s.FeedAt-1))
+		}
+	}
+
+	a.achievementsMu.Lock()
+	_, healthy := a.achievements.healthySince[t.ID]
+	a.achievementsMu.Unlock()
+	return min(n, keeps(next.health, d.health, healthy, healthyHealth))
+}
+
+// holdsFor returns for how many of the next n ticks of steady decay by d the
+// pet keeps the stats of the hold quests being watched in range. Each holds
+// a stat on one side of a line, and the stat moves one way, so it is in
+// range for a run of ticks from the first.
+func holdsFor(t *Tamagotchi, d needs, n int, holds []*questHold) int {
+	if len(holds) == 0 {
+		return n
+	}
+	ok := func(ticks int) bool {
+		probe := *t
+		probe.setNeeds(t.needs().after(ticks, d))
+		for _, h := range holds {
+			if !h.OK(h.Stat(&probe)) {
+				return false
+			}
+		}
+		return true
+	}
+	if n == 0 || !ok(1) {
+		return 0
+	}
+	lo, hi := 1, n // ok(lo) holds
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if ok(mid) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
+
+// quietAchievementsLocked reports whether ticks observed from at on unlock
+// nothing, and until when that holds, the zero time if for good: progress
+// only moves with the hours a pet stays healthy, as ages and stages are
+// already covered.
+func (a *App) quietAchievementsLocked(at time.Time) (time.Time, bool) {
+	a.achievementsMu.Lock()
+	defer a.achievementsMu.Unlock()
+
+	observedAt := a.achievements.observedAt
+	a.achievements.observedAt = at
+	defer func() { a.achievements.observedAt = observedAt }()
+
+	for _, ach := range achievementCatalog {
+		if _, ok := a.achievements.unlocked[ach.ID]; ok {
+			continue
+		}
+		if ach.progressLocked(a) >= ach.Goal {
+			return time.Time{}, false
+		}
+	}
+
+	healthy, ok := findAchievement("healthy_day")
+	if _, unlocked := a.achievements.unlocked[healthy.ID]; !ok || unlocked {
+		return time.Time{}, true
+	}
+	var until time.Time
+	for _, since := range a.achievements.healthySince {
+		next := since.Add(time.Duration(healthy.Goal) * time.Hour)
+		if until.IsZero() || next.Before(until) {
+			until = next
+		}
+	}
+	return until, true
+}
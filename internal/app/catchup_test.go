@@ -0,0 +1,220 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// catchUpApp returns a game catching up from start with the pets, sitter,
+// vacation and world events made by setup, which is run from the same seed
+// for every game. Games from the same seed also see the same world events.
+func catchUpApp(t *testing.T, clock *testClock, start time.Time, seed int64, setup func(rng *rand.Rand, a *App)) *App {
+	t.Helper()
+	a := newTestApp(t, clock)
+
+	a.stateMu.Lock()
+	a.worldRand = randv2.New(randv2.NewPCG(uint64(seed), uint64(seed)))
+	a.catchingUp = true
+	a.sitterOutOfFood = make(map[string]bool)
+	a.stats = nil
+	setup(rand.New(rand.NewSource(seed)), a)
+	a.currentTamagotchi = a.pets[0]
+	a.stateMu.Unlock()
+
+	a.eventsMu.Lock()
+	a.recordingOffline = true
+	a.eventsMu.Unlock()
+	return a
+}
+
+// randomPets sets up a roster of pets on every difficulty, with random
+// needs, toys, skills and ages, a sitter, a vacation and the built-in world
+// events, due at some point in the span or not yet scheduled.
+func randomPets(start time.Time, span time.Duration) func(rng *rand.Rand, a *App) {
+	return func(rng *rand.Rand, a *App) {
+		a.pets = nil
+		for i := 0; i < 1+rng.Intn(3); i++ {
+			balance := balancePresets[difficulties[rng.Intn(len(difficulties))]]
+			created := start.Add(-time.Duration(rng.Int63n(int64(10 * 24 * time.Hour))))
+			p := newTamagotchi(fmt.Sprintf("Pet%d", i), Genes{}, balance, created)
+			p.ID = fmt.Sprintf("pet-%d", i)
+			p.Hunger = rng.Intn(101)
+			p.Happiness = rng.Intn(101)
+			p.Energy = rng.Intn(101)
+			p.Health = 1 + rng.Intn(100)
+			p.Sick = rng.Intn(2) == 0
+			p.Age = p.ageOn(start)
+			p.Stage = stageForAge(p.Age)
+			p.tickDebt = time.Duration(rng.Int63n(int64(balance.UpdateInterval)))
+			if rng.Intn(2) == 0 {
+				p.Inventory["🧸 Teddy Bear"] = 1
+			}
+			if rng.Intn(2) == 0 {
+				p.Inventory["🎸 Toy Guitar"] = 1
+			}
+			if rng.Intn(3) == 0 {
+				p.Inventory = map[string]int{}
+			}
+			p.Skills = map[string]Skill{
+				"agility": {Level: 100 * rng.Float64(), LastTrained: start.Add(-time.Duration(rng.Int63n(int64(48 * time.Hour))))},
+				"smarts":  {Level: rng.Float64(), LastTrained: start.Add(-time.Duration(rng.Int63n(int64(48 * time.Hour))))},
+			}
+			a.pets = append(a.pets, p)
+
+			if p.Health > healthyHealth && rng.Intn(2) == 0 {
+				a.achievementsMu.Lock()
+				a.achievements.healthySince[p.ID] = start.Add(-time.Duration(rng.Int63n(int64(30 * time.Hour))))
+				a.achievementsMu.Unlock()
+			}
+		}
+
+		a.sitter = config.SitterConfig{
+			Enabled: rng.Intn(2) == 0,
+			FeedAt:  60 + rng.Intn(40),
+			SleepAt: rng.Intn(30),
+			BuyFood: rng.Intn(2) == 0,
+		}
+
+		switch rng.Intn(3) {
+		case 1:
+			a.vacation.Mode = vacationFreeze
+		case 2:
+			a.vacation.Mode = vacationSlow
+		}
+		a.vacation.Start = start.Add(time.Duration(rng.Int63n(int64(span))))
+		a.vacation.Until = a.vacation.Start.Add(time.Duration(rng.Int63n(int64(span))))
+
+		a.nextWorldEvent = time.Time{}
+		if rng.Intn(4) != 0 {
+			a.nextWorldEvent = start.Add(time.Duration(rng.Int63n(int64(span))))
+		}
+	}
+}
+
+// tickByTick is advanceTime without fast-forwarding.
+func tickByTick(a *App, elapsed time.Duration) {
+	a.stateMu.Lock()
+	defer a.stateMu.Unlock()
+
+	interval := a.tickIntervalLocked()
+	ticks := int(elapsed / interval)
+	end := a.now()
+	for i := 0; i < ticks; i++ {
+		a.applyTickLocked(end.Add(-time.Duration(ticks-1-i)*interval), interval)
+		if !a.anyAliveLocked() {
+			break
+		}
+	}
+}
+
+// comparablePets returns the pets with their skills checked against want and
+// then cleared, as those fade in floating point, a tick at a time or all at
+// once.
+func comparablePets(t *testing.T, pets, want []*Tamagotchi) []Tamagotchi {
+	t.Helper()
+	out := make([]Tamagotchi, len(pets))
+	for i, p := range pets {
+		out[i] = *p
+		if i < len(want) {
+			for name, s := range p.Skills {
+				if w := want[i].Skills[name]; math.Abs(s.Level-w.Level) > 1e-9 {
+					t.Errorf("%s's %s is %v, want %v", p.Name, name, s.Level, w.Level)
+				}
+			}
+		}
+		out[i].Skills = nil
+	}
+	return out
+}
+
+func statTicks(s statSeries) int {
+	n := 0
+	if len(s) > 0 {
+		for _, sample := range s[len(s)-1] {
+			n += sample.Count
+		}
+	}
+	return n
+}
+
+func TestFastForwardMatchesTickByTick(t *testing.T) {
+	for seed := int64(1); seed <= 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		start := time.Date(2026, time.Month(1+rng.Intn(12)), 1+rng.Intn(28), rng.Intn(24), rng.Intn(60), rng.Intn(60), 0, time.UTC)
+		span := time.Duration(1+rng.Int63n(int64(72*time.Hour)/int64(time.Minute))) * time.Minute
+		clock := newTestClock(start)
+
+		setup := randomPets(start, span)
+		fast := catchUpApp(t, clock, start, seed, setup)
+		slow := catchUpApp(t, clock, start, seed, setup)
+
+		clock.set(start.Add(span))
+		fast.advanceTime(span)
+		tickByTick(slow, span)
+
+		fastPets := comparablePets(t, fast.pets, slow.pets)
+		slowPets := comparablePets(t, slow.pets, nil)
+		if !reflect.DeepEqual(fastPets, slowPets) {
+			t.Fatalf("seed %d: fast-forwarded pets\n%+v\nwant\n%+v", seed, fastPets, slowPets)
+		}
+		if !reflect.DeepEqual(fast.offlineEvents, slow.offlineEvents) {
+			t.Fatalf("seed %d: fast-forward logged\n%v\nwant\n%v", seed, fast.offlineEvents, slow.offlineEvents)
+		}
+		if !reflect.DeepEqual(fast.achievements, slow.achievements) {
+			t.Fatalf("seed %d: fast-forward achievements %+v, want %+v", seed, fast.achievements, slow.achievements)
+		}
+		if !reflect.DeepEqual(fast.vacation, slow.vacation) || fast.sitterOutOfFood[fast.pets[0].ID] != slow.sitterOutOfFood[slow.pets[0].ID] {
+			t.Fatalf("seed %d: fast-forward left the vacation or sitter differently", seed)
+		}
+		if !fast.nextWorldEvent.Equal(slow.nextWorldEvent) || !reflect.DeepEqual(fast.offlineWorldEvents, slow.offlineWorldEvents) {
+			t.Fatalf("seed %d: fast-forward world events %v, next at %v, want %v, next at %v", seed,
+				fast.offlineWorldEvents, fast.nextWorldEvent, slow.offlineWorldEvents, slow.nextWorldEvent)
+		}
+		// Stat history is coarser fast-forwarded, but covers the same ticks.
+		for _, p := range slow.pets {
+			if got, want := statTicks(fast.stats[p.ID]), statTicks(slow.stats[p.ID]); got != want {
+				t.Fatalf("seed %d: %s's stats average %d ticks, want %d", seed, p.Name, got, want)
+			}
+		}
+	}
+}
+
+func TestFastForwardStopsAtHunger(t *testing.T) {
+	start := time.Date(2026, 5, 1, 19, 0, 0, 0, time.UTC)
+	clock := newTestClock(start)
+	a := newTestApp(t, clock)
+	a.worldEvents.Events = nil
+
+	// A first tick settles the day's quests.
+	interval := a.tickIntervalLocked()
+	clock.set(start.Add(interval))
+	a.advanceTime(interval)
+
+	a.stateMu.Lock()
+	defer a.stateMu.Unlock()
+	p := a.currentTamagotchi
+	p.Balance = balancePresets[difficultyClassic]
+	p.Hunger, p.Happiness, p.Energy, p.Health, p.Sick = 0, 50, 100, 50, false
+	p.Inventory = map[string]int{}
+	p.Skills = nil
+	delete(a.achievements.healthySince, p.ID)
+
+	// Hunger rises by 5 a tick: the 17th takes it past 80, the classic
+	// hungry threshold, so the 16 before it are quiet.
+	if n := a.fastForwardLocked(clock.now().Add(1000*interval), interval, 1, 1001); n != 16 {
+		t.Fatalf("fast-forwarded %d ticks, want 16", n)
+	}
+	if p.Hunger != 80 || p.Happiness != 50 || p.Energy != 52 {
+		t.Fatalf("needs after fast-forward are %d/%d/%d, want 80/50/52", p.Hunger, p.Happiness, p.Energy)
+	}
+	if got := statTicks(a.stats[p.ID]); got != 17 {
+		t.Fatalf("stats average %d ticks, want 17", got)
+	}
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+const sparklineWidth = 48
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+var chartWindows = []struct {
+	Label  string
+	Window time.Duration
+}{
+	{Label: "Last hour", Window: time.Hour},
+	{Label: "Last day", Window: 24 * time.Hour},
+	{Label: "Last week", Window: 7 * 24 * time.Hour},
+}
+
+var chartStats = []struct {
+	Name  string
+	Value func(config.StatSample) float64
+	Fixed bool // on the 0-100 scale rather than scaled to the data
+}{
+	{Name: "Hunger", Value: func(s config.StatSample) float64 { return s.Hunger }, Fixed: true},
+	{Name: "Happiness", Value: func(s config.StatSample) float64 { return s.Happiness }, Fixed: true},
+	{Name: "Health", Value: func(s config.StatSample) float64 { return s.Health }, Fixed: true},
+	{Name: "Energy", Value: func(s config.StatSample) float64 { return s.Energy }, Fixed: true},
+	{Name: "Weight", Value: func(s config.StatSample) float64 { return s.Weight }},
+}
+
+// sparkline draws values binned by time across the window ending at now.
+// Bins without samples are left blank.
+func sparkline(samples []config.StatSample, value func(config.StatSample) float64, fixed bool, window time.Duration, now time.Time) string {
+	sums := make([]float64, sparklineWidth)
+	counts := make([]int, sparklineWidth)
+	start := now.Add(-window)
+	for _, s := range samples {
+		bin := int(float64(s.Time.Sub(start)) / float64(window) * sparklineWidth)
+		if bin < 0 || bin >= sparklineWidth {
+			continue
+		}
+		sums[bin] += value(s)
+		counts[bin]++
+	}
+
+	lo, hi := 0.0, 100.0
+	if !fixed {
+		lo, hi = math.Inf(1), math.Inf(-1)
+		for i, c := range counts {
+			if c > 0 {
+				lo = math.Min(lo, sums[i]/float64(c))
+				hi = math.Max(hi, sums[i]/float64(c))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, c := range counts {
+		if c == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int((sums[i]/float64(c) - lo) / (hi - lo) * float64(len(sparkBlocks)))
+		}
+		level = max(0, min(len(sparkBlocks)-1, level))
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+func (a *App) generateChartsList(listCharts *tview.List) {
+	listCharts.Clear()
+
+	t, ok := a.tamagotchiSnapshot()
+	if !ok {
+		listCharts.AddItem("No tamagotchi available.", "", 0, nil)
+		return
+	}
+
+	listCharts.AddItem("=== WINDOW ===", "", 0, nil)
+	for i, w := range chartWindows {
+		index := i // Capture the index for the closure
+		marker := "( )"
+		if i == a.chartWindow {
+			marker = "(•)"
+		}
+		listCharts.AddItem(fmt.Sprintf("%s %s", marker, w.Label), "", 0, func() {
+			a.chartWindow = index
+			a.generateChartsList(listCharts)
+		})
+	}
+
+	window := chartWindows[a.chartWindow].Window
+	now := a.now()
+	samples := a.statsWindow(t.ID, window)
+
+	listCharts.AddItem("", "", 0, nil) // Empty line
+	listCharts.AddItem(fmt.Sprintf("=== %s: %s ===", strings.ToUpper(t.Name), chartWindows[a.chartWindow].Label), "", 0, nil)
+	if len(samples) == 0 {
+		listCharts.AddItem("No history recorded for this period yet.", "", 0, nil)
+		return
+	}
+
+	for _, stat := range chartStats {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, s := range samples {
+			lo = math.Min(lo, stat.Value(s))
+			hi = math.Max(hi, stat.Value(s))
+		}
+		last := stat.Value(samples[len(samples)-1])
+
+		listCharts.AddItem(fmt.Sprintf("%-10s %s", stat.Name, sparkline(samples, stat.Value, stat.Fixed, window, now)), "", 0, nil)
+		listCharts.AddItem(fmt.Sprintf("%-10s now %.0f, min %.0f, max %.0f", "", last, lo, hi), "", 0, nil)
+	}
+
+	listCharts.AddItem("", "", 0, nil) // Empty line
+	listCharts.AddItem(fmt.Sprintf("%s ago%s now", formatDuration(window), strings.Repeat(" ", max(1, sparklineWidth-len(formatDuration(window))-7))), "", 0, nil)
+}
+
+func (a *App) chartsPage() (title string, content tview.Primitive) {
+	listCharts := a.viewsList["charts"]
+	if listCharts == nil {
+		listCharts = getList()
+		a.viewsList["charts"] = listCharts
+	}
+
+	a.generateChartsList(listCharts)
+
+	title = chartsSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listCharts, 0, 1, true), 0, 1, true)
+}
@@ -0,0 +1,511 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// The daemon keeps the game ticking in real time without the TUI. The game
+// and the CLI talk to it over a Unix socket in JSON-RPC 2.0, one message per
+// line. Subscribing to events makes the daemon send an "event" notification
+// for each one logged from then on.
+
+const (
+	methodStatus    = "status"
+	methodState     = "state"
+	methodFeed      = "feed"
+	methodPlay      = "play"
+	methodSleep     = "sleep"
+	methodVacation  = "vacation"
+	methodSitter    = "sitter"
+	methodNotify    = "notify"
+	methodWebhooks  = "webhooks"
+	methodSave      = "save"
+	methodSubscribe = "subscribe"
+	methodEvent     = "event"
+
+	vacationStart = "start"
+	vacationEnd   = "end"
+
+	// daemonSaveInterval is how often the daemon writes the save, so the
+	// CLI and a crash never lose much.
+	daemonSaveInterval = time.Minute
+
+	// subscriberBuffer is how many events a slow subscriber can fall behind
+	// before it misses some.
+	subscriberBuffer = 64
+)
+
+// JSON-RPC error codes. Game errors, such as feeding a dead pet, are
+// rpcGameError.
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcGameError      = -32000
+)
+
+// rpcMessage is a request, a response or a notification.
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// petParams name a pet; no name means the current one, or every pet for
+// status and subscribe.
+type petParams struct {
+	Pet string `json:"pet,omitempty"`
+}
+
+type actionParams struct {
+	Pet    string `json:"pet,omitempty"`
+	Food   string `json:"food,omitempty"`   // feed
+	Game   string `json:"game,omitempty"`   // play
+	Option string `json:"option,omitempty"` // sleep
+}
+
+type vacationParams struct {
+	Action string `json:"action"` // start or end
+	Mode   string `json:"mode,omitempty"`
+	Days   int    `json:"days,omitempty"`
+}
+
+// PetStatus is how a pet is doing, as the daemon and the status command
+// report it.
+type PetStatus struct {
+	Name      string         `json:"name"`
+	Stage     string         `json:"stage"`
+	Form      string         `json:"form,omitempty"`
+	Age       int            `json:"age"`
+	Hunger    int            `json:"hunger"`
+	Happiness int            `json:"happiness"`
+	Health    int            `json:"health"`
+	Energy    int            `json:"energy"`
+	Weight    float64        `json:"weight"`
+	Coins     int            `json:"coins"`
+	Inventory map[string]int `json:"inventory"`
+	Alive     bool           `json:"alive"`
+	Sick      bool           `json:"sick"`
+	Hardcore  bool           `json:"hardcore"`
+	Current   bool           `json:"current"`
+}
+
+// petStatuses reports on the named pet, or on every pet.
+func petStatuses(pets []Tamagotchi, current, only string) ([]PetStatus, error) {
+	var statuses []PetStatus
+	for _, t := range pets {
+		if only != "" && t.Name != only {
+			continue
+		}
+		statuses = append(statuses, PetStatus{
+			Name:      t.Name,
+			Stage:     t.Stage,
+			Form:      t.Form,
+			Age:       t.Age,
+			Hunger:    t.Hunger,
+			Happiness: t.Happiness,
+			Health:    t.Health,
+			Energy:    t.Energy,
+			Weight:    t.Weight,
+			Coins:     t.Coins,
+			Inventory: copyInventory(t.Inventory),
+			Alive:     t.IsAlive,
+			Sick:      t.Sick,
+			Hardcore:  t.Hardcore,
+			Current:   t.Name == current,
+		})
+	}
+	if only != "" && len(statuses) == 0 {
+		return nil, fmt.Errorf("no pet named %q", only)
+	}
+	return statuses, nil
+}
+
+// PetStatuses reports on the pets in cfg as they were last saved, for the
+// named pet or every pet.
+func PetStatuses(cfg *config.Config, pet string) ([]PetStatus, error) {
+	pets := make([]Tamagotchi, 0, len(cfg.Pets))
+	for _, p := range cfg.Pets {
+		pets = append(pets, *tamagotchiFromConfig(p))
+	}
+	return petStatuses(pets, cfg.App.ActivePet, pet)
+}
+
+// DaemonOptions are the daemon's optional extras.
+type DaemonOptions struct {
+	// APIAddr is where to serve the HTTP API, if anywhere.
+	APIAddr string
+	// APIToken is the token the HTTP API asks for.
+	APIToken string
+}
+
+// RunDaemon keeps the game in cfg ticking without the TUI and serves the
+// control socket, and the HTTP API if opts say where, until interrupted,
+// saving as it goes.
+func RunDaemon(cfg *config.Config, opts DaemonOptions) error {
+	path, err := config.SocketPath()
+	if err != nil {
+		return err
+	}
+	if c, err := DialDaemon(); err == nil {
+		c.Close()
+		return fmt.Errorf("a daemon is already running on %s", path)
+	}
+	lock, err := config.LockSave()
+	if err != nil {
+		return fmt.Errorf("%w: close the game first", err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	// Nobody answers, so the socket is left over from a daemon that didn't
+	// shut down cleanly.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	if err := os.Chmod(path, 0600); err != nil {
+		return err
+	}
+	var apiListener net.Listener
+	if opts.APIAddr != "" {
+		if opts.APIToken == "" {
+			return errors.New("the HTTP API needs a token")
+		}
+		if apiListener, err = net.Listen("tcp", opts.APIAddr); err != nil {
+			return err
+		}
+		defer apiListener.Close()
+	}
+
+	a := NewApp(cfg)
+	a.stateMu.Lock()
+	a.headless = true
+	a.stateMu.Unlock()
+	a.save()
+
+	go a.serveRPC(l)
+	go a.watchPets()
+	go a.runWebhooks()
+	log.Printf("daemon listening on %s", path)
+
+	// Cancelling the server's context ends the event streams, which would
+	// otherwise hold up its shutdown.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var server *http.Server
+	if apiListener != nil {
+		server = &http.Server{
+			Handler:           a.apiHandler(opts.APIToken),
+			ReadHeaderTimeout: 10 * time.Second,
+			BaseContext:       func(net.Listener) context.Context { return ctx },
+		}
+		go func() {
+			if err := server.Serve(apiListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("HTTP API: %v", err)
+			}
+		}()
+		log.Printf("HTTP API listening on http://%s/api/", apiListener.Addr())
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	ticker := time.NewTicker(daemonSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.save()
+		case <-stop:
+			if server != nil {
+				cancel()
+				shutdown, done := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := server.Shutdown(shutdown); err != nil {
+					log.Printf("HTTP API: %v", err)
+				}
+				done()
+			}
+			a.save()
+			a.closeJournal()
+			return nil
+		}
+	}
+}
+
+func (a *App) serveRPC(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("daemon: %v", err)
+			}
+			return
+		}
+		go a.serveConn(conn)
+	}
+}
+
+func (a *App) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	var sendMu sync.Mutex // responses and notifications share the connection
+	enc := json.NewEncoder(conn)
+	send := func(msg rpcMessage) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return enc.Encode(msg)
+	}
+
+	var unsubscribe []func()
+	defer func() {
+		for _, cancel := range unsubscribe {
+			cancel()
+		}
+	}()
+
+	dec := json.NewDecoder(conn)
+	for {
+		var req rpcMessage
+		if err := dec.Decode(&req); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				_ = send(rpcMessage{JSONRPC: "2.0", Error: &rpcError{Code: rpcParseError, Message: err.Error()}})
+			}
+			return
+		}
+
+		var events <-chan GameEvent
+		var result any
+		var err error
+		if req.Method == methodSubscribe {
+			var p petParams
+			if err = decodeParams(req.Params, &p); err == nil {
+				var cancel func()
+				events, cancel = a.subscribe(p.Pet)
+				unsubscribe = append(unsubscribe, cancel)
+				result = true
+			}
+		} else {
+			result, err = a.handleRPC(req.Method, req.Params)
+		}
+
+		if req.ID != nil {
+			if err := send(rpcResponse(req.ID, result, err)); err != nil {
+				return
+			}
+		}
+
+		// Events only follow the response, so clients can tell them apart.
+		if events != nil {
+			go func() {
+				for event := range events {
+					params, _ := json.Marshal(event)
+					if send(rpcMessage{JSONRPC: "2.0", Method: methodEvent, Params: params}) != nil {
+						return
+					}
+				}
+			}()
+		}
+	}
+}
+
+func rpcResponse(id json.RawMessage, result any, err error) rpcMessage {
+	resp := rpcMessage{JSONRPC: "2.0", ID: id}
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: rpcGameError, Message: err.Error()}
+		}
+		resp.Error = rerr
+		return resp
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &rpcError{Code: rpcGameError, Message: err.Error()}
+		return resp
+	}
+	resp.Result = raw
+	return resp
+}
+
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (a *App) handleRPC(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case methodStatus:
+		var p petParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return a.statuses(p.Pet)
+
+	case methodState:
+		return a.stateSnapshot(), nil
+
+	case methodFeed, methodPlay, methodSleep:
+		var p actionParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if err := a.runAction(method, p); err != nil {
+			return nil, err
+		}
+		return a.statuses(p.Pet)
+
+	case methodVacation:
+		var p vacationParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		var err error
+		switch p.Action {
+		case vacationStart:
+			err = a.takeVacation(p.Mode, p.Days)
+		case vacationEnd:
+			err = a.cutVacationShort()
+		default:
+			err = &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("unknown vacation action %q, want %s or %s", p.Action, vacationStart, vacationEnd)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.stateMu.RLock()
+		v := a.vacation
+		a.stateMu.RUnlock()
+		return vacationStatus(v, a.now()), nil
+
+	case methodSitter:
+		var s config.SitterConfig
+		if err := decodeParams(params, &s); err != nil {
+			return nil, err
+		}
+		if s.FeedAt < 1 || s.FeedAt > 100 || s.SleepAt < 1 || s.SleepAt > 100 {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "sitter thresholds must be between 1 and 100"}
+		}
+		a.updateSitter(func(sitter *config.SitterConfig) { *sitter = s })
+		return DescribeSitter(s), nil
+
+	case methodNotify:
+		var s config.NotifyConfig
+		if err := decodeParams(params, &s); err != nil {
+			return nil, err
+		}
+		s = WithNotifyDefaults(s)
+		if err := ValidateNotify(s); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		a.updateNotify(s)
+		return DescribeNotify(s), nil
+
+	case methodWebhooks:
+		var hooks []config.WebhookConfig
+		if err := decodeParams(params, &hooks); err != nil {
+			return nil, err
+		}
+		if err := ValidateWebhooks(hooks); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		a.updateWebhooks(hooks)
+		descriptions := make([]string, 0, len(hooks))
+		for _, h := range hooks {
+			descriptions = append(descriptions, DescribeWebhook(h))
+		}
+		return descriptions, nil
+
+	case methodSave:
+		a.save()
+		return nil, nil
+	}
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("unknown method %q", method)}
+}
+
+func (a *App) statuses(pet string) ([]PetStatus, error) {
+	pets := a.petsSnapshot()
+	current, _ := a.tamagotchiSnapshot()
+	return petStatuses(pets, current.Name, pet)
+}
+
+// runAction takes care of a pet here and now.
+func (a *App) runAction(method string, p actionParams) error {
+	switch method {
+	case methodFeed:
+		return a.feedPet(p.Pet, p.Food)
+	case methodPlay:
+		return a.playPet(p.Pet, p.Game)
+	case methodSleep:
+		return a.sleepPet(p.Pet, p.Option)
+	}
+	return &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("unknown action %q", method)}
+}
+
+// subscribe returns a stream of the events of the named pet, or of every
+// pet, and the function that ends it.
+func (a *App) subscribe(pet string) (<-chan GameEvent, func()) {
+	events := make(chan GameEvent, subscriberBuffer)
+
+	a.eventsMu.Lock()
+	a.subscribers[events] = pet
+	a.eventsMu.Unlock()
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			a.eventsMu.Lock()
+			delete(a.subscribers, events)
+			a.eventsMu.Unlock()
+			close(events)
+		})
+	}
+}
+
+// publishLocked hands an event to its subscribers, skipping those too far
+// behind to take it.
+func (a *App) publishLocked(event GameEvent) {
+	for events, pet := range a.subscribers {
+		if pet != "" && pet != event.Pet {
+			continue
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
@@ -2,55 +2,303 @@ package app
 
 import (
 	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+// eventsPageSize is how many more events "Load older events" fetches.
+const eventsPageSize = 50
+
+// eventIcons maps event types to the icon shown on the Events page. The
+// order of eventTypes is the order of the type filter.
+var (
+	eventTypes = []string{"FEED", "PLAY", "SLEEP", "EVOLUTION", "DEATH", "RESTART", "PROGRESS", "BIRTH", "ADOPT", "SHOP", "WORK", "HEAL", "ITEM", "ACHIEVEMENT", "QUEST", "WORLD", "HOLIDAY", "VACATION", "SITTER", "TAMPER", "CLOCK", "SICK", "DAEMON", "ALERT"}
+	eventIcons = map[string]string{
+		"FEED":        "🍽️",
+		"PLAY":        "🎮",
+		"SLEEP":       "😴",
+		"EVOLUTION":   "🌟",
+		"DEATH":       "💔",
+		"RESTART":     "🔄",
+		"PROGRESS":    "⏳",
+		"BIRTH":       "👶",
+		"ADOPT":       "🥚",
+		"SHOP":        "🛒",
+		"WORK":        "💼",
+		"HEAL":        "💊",
+		"ITEM":        "🎩",
+		"ACHIEVEMENT": "🏆",
+		"QUEST":       "📜",
+		"WORLD":       "🌍",
+		"HOLIDAY":     "🎉",
+		"VACATION":    "🏖️",
+		"SITTER":      "🤝",
+		"TAMPER":      "⚠️",
+		"CLOCK":       "🕰️",
+		"SICK":        "🤒",
+		"DAEMON":      "🔌",
+		"ALERT":       "🔔",
+	}
+)
+
+// eventRanges are the date range jumps offered by the Events page.
+var eventRanges = []struct {
+	Label string
+	Since time.Duration // zero for all time
+}{
+	{Label: "All time"},
+	{Label: "Last hour", Since: time.Hour},
+	{Label: "Last 24 hours", Since: 24 * time.Hour},
+	{Label: "Last 7 days", Since: 7 * 24 * time.Hour},
+	{Label: "Last 30 days", Since: 30 * 24 * time.Hour},
+}
+
+// eventFilter selects events from the persisted history. Zero fields match
+// everything.
+type eventFilter struct {
+	Pet    string
+	Type   string
+	Search string // case-insensitive substring of the message
+	Since  time.Duration
+	Until  time.Time // events on or before this day
+}
+
+func (f eventFilter) matches(event GameEvent, now time.Time) bool {
+	if f.Pet != "" && event.Pet != f.Pet {
+		return false
+	}
+	if f.Type != "" && event.Type != f.Type {
+		return false
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(event.Message), strings.ToLower(f.Search)) {
+		return false
+	}
+	if f.Since > 0 && event.Timestamp.Before(now.Add(-f.Since)) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.Timestamp.Before(f.Until.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+func eventIcon(eventType string) string {
+	if icon, ok := eventIcons[eventType]; ok {
+		return icon
+	}
+	return "📝"
+}
+
+// eventsHistory is the part of the journal the Events page has read for its
+// filter, and where to carry on reading older events from. It is only touched
+// by the UI goroutine; the journal is read on a copy.
+type eventsHistory struct {
+	filter  eventFilter
+	events  []GameEvent          // matching events read so far, newest first
+	files   []string             // journal files not read yet, oldest first
+	records []config.EventRecord // the file being read
+	next    int                  // index in records of the next older one
+	done    bool                 // the whole journal has been read
+	loading bool                 // older events are being read
+	gen     int                  // counts resets, so a read for an old filter is dropped
+}
+
+// queryEvents returns up to limit events matching the filter, newest first,
+// and whether there may be older ones. They come from the recent events kept
+// in memory, followed by those read from the journal for the same filter; the
+// journal itself is only read when the filter changes from the default one or
+// older events are asked for.
+func (a *App) queryEvents(filter eventFilter, limit int) ([]GameEvent, bool) {
+	if a.eventsHistory.filter != filter {
+		a.resetEventsHistory(filter)
+		if filter != (eventFilter{Pet: filter.Pet}) {
+			a.readOlderEvents(limit+1, a.refreshEventsList)
+		}
+	}
+	h := &a.eventsHistory
+
+	now := a.now()
+	var events []GameEvent
+	recent := a.eventsSnapshot()
+	for i := len(recent) - 1; i >= 0; i-- {
+		if len(h.events) > 0 && !recent[i].Timestamp.After(h.events[0].Timestamp) {
+			break // read from the journal already
+		}
+		if filter.matches(recent[i], now) {
+			events = append(events, recent[i])
+		}
+	}
+	events = append(events, h.events...)
+
+	if len(events) > limit {
+		return events[:limit], true
+	}
+	return events, !h.done
+}
+
+// resetEventsHistory forgets what was read from the journal and starts again
+// from its newest file for a new filter.
+func (a *App) resetEventsHistory(filter eventFilter) {
+	a.eventsHistory = eventsHistory{filter: filter, done: true, gen: a.eventsHistory.gen + 1}
+	if a.journal == nil && a.remote == nil {
+		// No journal: only the in-memory events are available. Clients
+		// read the one the daemon writes.
+		return
+	}
+	dir, err := config.Dir()
+	var files []string
+	if err == nil {
+		files, err = config.JournalFiles(dir)
+	}
+	if err != nil {
+		log.Printf("failed to read event journal: %v", err)
+		return
+	}
+	a.eventsHistory.files = files
+	a.eventsHistory.done = false
+}
+
+// readOlderEvents reads back through the journal from where the Events page
+// left off until it has n matching events. While the TUI runs that happens
+// off the UI goroutine, since the journal is never pruned, and then is called
+// back on it; otherwise the events are read right away and then isn't.
+func (a *App) readOlderEvents(n int, then func()) {
+	h := a.eventsHistory
+	if h.loading || h.done || len(h.events) >= n {
+		return
+	}
+	now := a.now()
+	if !a.tuiIsRunning() {
+		a.eventsHistory.readOlder(n, now)
+		return
+	}
+
+	a.eventsHistory.loading = true
+	h.events = slices.Clone(h.events)
+	go func() {
+		h.readOlder(n, now)
+		a.TApp.QueueUpdateDraw(func() {
+			if a.eventsHistory.gen != h.gen {
+				return // the filter changed meanwhile
+			}
+			h.loading = false
+			a.eventsHistory = h
+			then()
+		})
+	}()
+}
+
+// readOlder reads back through the journal until h has n matching events,
+// one file at a time.
+func (h *eventsHistory) readOlder(n int, now time.Time) {
+	for len(h.events) < n && !h.done {
+		if h.next == 0 {
+			if len(h.files) == 0 {
+				h.done = true
+				break
+			}
+			path := h.files[len(h.files)-1]
+			h.files = h.files[:len(h.files)-1]
+			records, err := config.ReadJournalFile(path)
+			if err != nil {
+				log.Printf("failed to read part of the event journal: %v", err)
+			}
+			h.records, h.next = records, len(records)
+			continue
+		}
+
+		h.next--
+		if event := eventFromRecord(h.records[h.next]); h.filter.matches(event, now) {
+			h.events = append(h.events, event)
+		}
+	}
+}
+
 func (a *App) generateEventsList(listEvents *tview.List) {
 	listEvents.Clear()
 
-	events := a.eventsSnapshot()
+	t, ok := a.tamagotchiSnapshot()
+	if !ok {
+		listEvents.AddItem("No tamagotchi available.", "", 0, nil)
+		return
+	}
+
+	filter := a.eventsFilter
+	filter.Pet = t.Name
+	if a.eventsLimit <= 0 {
+		a.eventsLimit = eventsPageSize
+	}
+
+	events, more := a.queryEvents(filter, a.eventsLimit)
+	loading := a.eventsHistory.loading
 
 	if len(events) == 0 {
-		listEvents.AddItem("No events yet!", "", 0, nil)
-		listEvents.AddItem("Start interacting with your tamagotchi to see events.", "", 0, nil)
+		if loading {
+			listEvents.AddItem("⏳ Searching older events...", "", 0, nil)
+		} else if filter == (eventFilter{Pet: t.Name}) {
+			listEvents.AddItem("No events yet!", "", 0, nil)
+			listEvents.AddItem("Start interacting with your tamagotchi to see events.", "", 0, nil)
+		} else {
+			listEvents.AddItem("No events match the current filters.", "", 0, nil)
+		}
 		return
 	}
 
-	listEvents.AddItem("=== GAME EVENTS ===", "", 0, nil)
+	listEvents.AddItem(fmt.Sprintf("=== GAME EVENTS (%d shown) ===", len(events)), "", 0, nil)
 	listEvents.AddItem("", "", 0, nil) // Empty line
 
-	// Show events in reverse chronological order (newest first)
-	for i := len(events) - 1; i >= 0; i-- {
-		event := events[i]
-		timeStr := event.Timestamp.Format("15:04")
-
-		// Add color coding based on event type
-		var eventIcon string
-		switch event.Type {
-		case "FEED":
-			eventIcon = "🍽️"
-		case "PLAY":
-			eventIcon = "🎮"
-		case "SLEEP":
-			eventIcon = "😴"
-		case "EVOLUTION":
-			eventIcon = "🌟"
-		case "DEATH":
-			eventIcon = "💔"
-		case "RESTART":
-			eventIcon = "🔄"
-		default:
-			eventIcon = "📝"
+	// Events arrive in reverse chronological order (newest first)
+	lastDay := ""
+	for _, event := range events {
+		if day := event.Timestamp.Format("Mon 2006-01-02"); day != lastDay {
+			listEvents.AddItem(fmt.Sprintf("--- %s ---", day), "", 0, nil)
+			lastDay = day
 		}
 
+		timeStr := event.Timestamp.Format("15:04")
 		listEvents.AddItem(
-			fmt.Sprintf("%s [%s] %s", eventIcon, timeStr, event.Message),
+			fmt.Sprintf("%s [%s] %s", eventIcon(event.Type), timeStr, event.Message),
 			"",
 			0,
 			nil,
 		)
 	}
+
+	switch {
+	case loading:
+		listEvents.AddItem("", "", 0, nil) // Empty line
+		listEvents.AddItem("⏳ Reading older events...", "", 0, nil)
+	case more:
+		listEvents.AddItem("", "", 0, nil) // Empty line
+		listEvents.AddItem("⬇️ Load older events", "", 0, func() {
+			a.eventsLimit += eventsPageSize
+			a.readOlderEvents(a.eventsLimit+1, func() {
+				a.refreshEventsList()
+				listEvents.SetCurrentItem(-1)
+			})
+			a.generateEventsList(listEvents)
+			listEvents.SetCurrentItem(-1)
+		})
+	}
+}
+
+func (a *App) refreshEventsList() {
+	if list := a.viewsList["events"]; list != nil {
+		a.generateEventsList(list)
+	}
+}
+
+// setEventsFilter applies a new filter and starts again from the first page.
+func (a *App) setEventsFilter(update func(f *eventFilter)) {
+	update(&a.eventsFilter)
+	a.eventsLimit = eventsPageSize
+	a.refreshEventsList()
 }
 
 func (a *App) eventsPage() (title string, content tview.Primitive) {
@@ -62,9 +310,85 @@ func (a *App) eventsPage() (title string, content tview.Primitive) {
 
 	a.generateEventsList(listEvents)
 
+	typeFilter := tview.NewDropDown().SetLabel("Type: ")
+	typeFilter.SetOptions(append([]string{"ALL"}, eventTypes...), func(text string, index int) {
+		a.setEventsFilter(func(f *eventFilter) {
+			f.Type = ""
+			if index > 0 {
+				f.Type = text
+			}
+		})
+	})
+	typeFilter.SetCurrentOption(0)
+
+	rangeLabels := make([]string, 0, len(eventRanges))
+	for _, r := range eventRanges {
+		rangeLabels = append(rangeLabels, r.Label)
+	}
+	rangeFilter := tview.NewDropDown().SetLabel("Range: ")
+	rangeFilter.SetOptions(rangeLabels, func(text string, index int) {
+		a.setEventsFilter(func(f *eventFilter) { f.Since = eventRanges[index].Since })
+	})
+	rangeFilter.SetCurrentOption(0)
+
+	// Searching reads the journal, so it waits for Enter rather than going
+	// through it on every key.
+	search := tview.NewInputField().SetLabel("Search: ").SetPlaceholder("Enter to search")
+	search.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			a.setEventsFilter(func(f *eventFilter) { f.Search = strings.TrimSpace(search.GetText()) })
+		}
+	})
+
+	jump := tview.NewInputField().
+		SetLabel("Up to date: ").
+		SetPlaceholder("YYYY-MM-DD").
+		SetFieldWidth(12)
+	jump.SetDoneFunc(func(key tcell.Key) {
+		text := strings.TrimSpace(jump.GetText())
+		day, err := time.ParseInLocation("2006-01-02", text, time.Local)
+		if text != "" && err != nil {
+			jump.SetLabel("Up to date (YYYY-MM-DD!): ")
+			return
+		}
+		jump.SetLabel("Up to date: ")
+		a.setEventsFilter(func(f *eventFilter) { f.Until = day })
+	})
+
+	controls := tview.NewFlex().
+		AddItem(typeFilter, 0, 1, false).
+		AddItem(rangeFilter, 0, 1, false).
+		AddItem(search, 0, 2, false).
+		AddItem(jump, 0, 1, false)
+
+	// Tab walks through the filters and back to the list.
+	focusOrder := []tview.Primitive{listEvents, typeFilter, rangeFilter, search, jump}
+	for i, p := range focusOrder {
+		next := focusOrder[(i+1)%len(focusOrder)]
+		prev := focusOrder[(i+len(focusOrder)-1)%len(focusOrder)]
+		box, ok := p.(interface {
+			SetInputCapture(func(*tcell.EventKey) *tcell.EventKey) *tview.Box
+		})
+		if !ok {
+			continue
+		}
+		box.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			switch event.Key() {
+			case tcell.KeyTab:
+				a.TApp.SetFocus(next)
+				return nil
+			case tcell.KeyBacktab:
+				a.TApp.SetFocus(prev)
+				return nil
+			}
+			return event
+		})
+	}
+
 	title = eventsSection
 	return title, tview.NewFlex().
 		AddItem(tview.NewFlex().
 			SetDirection(tview.FlexRow).
+			AddItem(controls, 1, 0, false).
 			AddItem(listEvents, 0, 1, true), 0, 1, true)
 }
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestQueryEventsPagesFromMemoryThenJournal(t *testing.T) {
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := newTestClock(start)
+	a := newTestApp(t, clock)
+	name := setCurrentPet(a, func(t *Tamagotchi) {})
+
+	for i := 0; i < 120; i++ {
+		clock.set(start.Add(time.Duration(i+1) * time.Minute))
+		eventType := "PLAY"
+		if i%2 == 0 {
+			eventType = "FEED"
+		}
+		a.addPetEvent(name, eventType, fmt.Sprintf("event %d", i))
+	}
+
+	check := func(events []GameEvent, newest, step int) {
+		t.Helper()
+		for i, event := range events {
+			if want := fmt.Sprintf("event %d", newest-i*step); event.Message != want {
+				t.Fatalf("event %d is %q, want %q", i, event.Message, want)
+			}
+		}
+	}
+
+	filter := eventFilter{Pet: name}
+	events, more := a.queryEvents(filter, eventsPageSize)
+	if len(events) != eventsPageSize || !more {
+		t.Fatalf("first page: %d events, more %v; want %d and more", len(events), more, eventsPageSize)
+	}
+	check(events, 119, 1)
+	if len(a.eventsHistory.events) != 0 {
+		t.Errorf("the first page read %d events from the journal, want none", len(a.eventsHistory.events))
+	}
+
+	// Load older events.
+	a.readOlderEvents(2*eventsPageSize+1, nil)
+	events, more = a.queryEvents(filter, 2*eventsPageSize)
+	if len(events) != 2*eventsPageSize || !more {
+		t.Fatalf("second page: %d events, more %v; want %d and more", len(events), more, 2*eventsPageSize)
+	}
+	check(events, 119, 1)
+
+	// A newer event still shows on top of those read from the journal.
+	clock.set(start.Add(3 * time.Hour))
+	a.addPetEvent(name, "PLAY", "event 120")
+	events, _ = a.queryEvents(filter, 3*eventsPageSize)
+	check(events[:2], 120, 1)
+
+	// A new filter starts again from the journal.
+	filter.Type = "FEED"
+	events, more = a.queryEvents(filter, eventsPageSize)
+	if len(events) != eventsPageSize || !more {
+		t.Fatalf("FEED: %d events, more %v; want %d and more", len(events), more, eventsPageSize)
+	}
+	check(events, 118, 2)
+	a.readOlderEvents(2*eventsPageSize+1, nil)
+	events, more = a.queryEvents(filter, 2*eventsPageSize)
+	if len(events) != 60 || more {
+		t.Fatalf("FEED: %d events, more %v; want 60 and no more", len(events), more)
+	}
+}
+
+func TestReadOlderEventsOffTheUIGoroutine(t *testing.T) {
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := newTestClock(start)
+	a := newTestApp(t, clock)
+	name := setCurrentPet(a, func(t *Tamagotchi) {})
+	for i := 0; i < 80; i++ {
+		clock.set(start.Add(time.Duration(i+1) * time.Minute))
+		a.addPetEvent(name, "FEED", fmt.Sprintf("event %d", i))
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	a.TApp.SetScreen(screen).SetRoot(tview.NewBox(), true)
+	stopped := make(chan struct{})
+	go func() {
+		_ = a.TApp.Run()
+		close(stopped)
+	}()
+	t.Cleanup(func() {
+		a.TApp.Stop()
+		<-stopped
+	})
+	a.uiMu.Lock()
+	a.tuiRunning = true
+	a.uiMu.Unlock()
+
+	read := make(chan []GameEvent)
+	a.TApp.QueueUpdate(func() {
+		a.resetEventsHistory(eventFilter{Pet: name, Search: "event 1"})
+		a.readOlderEvents(5, func() { read <- a.eventsHistory.events })
+		if !a.eventsHistory.loading {
+			t.Error("the journal was read on the UI goroutine")
+		}
+	})
+	select {
+	case events := <-read:
+		if len(events) != 5 || events[0].Message != "event 19" {
+			t.Fatalf("read %v, want 5 events from event 19 down", events)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("older events never arrived")
+	}
+}
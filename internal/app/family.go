@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+func (a *App) generateFamilyList(listFamily *tview.List) {
+	listFamily.Clear()
+
+	t, ok := a.tamagotchiSnapshot()
+	if !ok {
+		listFamily.AddItem("No tamagotchi available.", "", 0, nil)
+		return
+	}
+
+	listFamily.AddItem(fmt.Sprintf("%s: generation %d, genes: %s", t.Name, t.Generation, t.Genes.describe()), "", 0, nil)
+	listFamily.AddItem("", "", 0, nil) // Empty line
+
+	listFamily.AddItem("=== HAVE A BABY ===", "", 0, nil)
+	now := a.now()
+	switch {
+	case !t.IsAlive:
+		listFamily.AddItem("Your tamagotchi has passed away... 💔", "", 0, nil)
+	case t.Stage != "adult":
+		listFamily.AddItem("Only adults can have babies.", "", 0, nil)
+	case !t.canBreed(now):
+		if wait := breedingCooldown - now.Sub(t.LastBred); wait > 0 {
+			listFamily.AddItem(fmt.Sprintf("Resting after the last baby, ready in %s", formatDuration(wait)), "", 0, nil)
+		} else {
+			listFamily.AddItem(fmt.Sprintf("Needs at least %d energy to have a baby.", breedingEnergy), "", 0, nil)
+		}
+	default:
+		listFamily.AddItem("🥚 Have a baby alone", "", 0, func() { a.breedTamagotchi("") })
+		for _, partner := range a.petsSnapshot() {
+			if partner.Name == t.Name || !partner.canBreed(now) {
+				continue
+			}
+			name := partner.Name // Capture the name for the closure
+			listFamily.AddItem(
+				fmt.Sprintf("💞 Have a baby with %s (%s)", partner.Name, partner.Genes.describe()),
+				"",
+				0,
+				func() { a.breedTamagotchi(name) },
+			)
+		}
+	}
+
+	listFamily.AddItem("", "", 0, nil) // Empty line
+	listFamily.AddItem("=== FAMILY TREE ===", "", 0, nil)
+
+	alive := make(map[string]bool)
+	for _, pet := range a.petsSnapshot() {
+		alive[pet.ID] = pet.IsAlive
+	}
+
+	lineage := a.lineageSnapshot()
+	byID := make(map[string]LineageEntry, len(lineage))
+	for _, entry := range lineage {
+		byID[entry.ID] = entry
+	}
+
+	// Babies hang under their first parent; the other parent is named.
+	children := make(map[string][]LineageEntry)
+	var roots []LineageEntry
+	for _, entry := range lineage {
+		if len(entry.Parents) > 0 {
+			if _, known := byID[entry.Parents[0]]; known {
+				children[entry.Parents[0]] = append(children[entry.Parents[0]], entry)
+				continue
+			}
+		}
+		roots = append(roots, entry)
+	}
+
+	var addBranch func(entry LineageEntry, depth int)
+	addBranch = func(entry LineageEntry, depth int) {
+		status := "🪦"
+		if isAlive, inRoster := alive[entry.ID]; inRoster {
+			status = "🟢"
+			if !isAlive {
+				status = "🔴"
+			}
+		}
+
+		label := fmt.Sprintf("%s[%s]%s[-] (gen %d, born %s)", status, entry.Color, entry.Name, entry.Generation, entry.Born.Format("2006-01-02"))
+		if len(entry.Parents) > 1 {
+			if other, ok := byID[entry.Parents[1]]; ok {
+				label += fmt.Sprintf(" with %s", other.Name)
+			}
+		}
+
+		prefix := ""
+		if depth > 0 {
+			prefix = strings.Repeat("   ", depth-1) + "└─ "
+		}
+		listFamily.AddItem(prefix+label, "", 0, nil)
+
+		for _, child := range children[entry.ID] {
+			addBranch(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		addBranch(root, 0)
+	}
+}
+
+func (a *App) familyPage() (title string, content tview.Primitive) {
+	listFamily := a.viewsList["family"]
+	if listFamily == nil {
+		listFamily = getList()
+		a.viewsList["family"] = listFamily
+	}
+
+	a.generateFamilyList(listFamily)
+
+	title = familySection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listFamily, 0, 1, true), 0, 1, true)
+}
@@ -8,14 +8,14 @@ import (
 )
 
 var availableFoods = []Food{
-	{Name: "🍎 Apple", Nutrition: 20, Happiness: 5, Energy: 10, WeightGain: 0.5},
-	{Name: "🍕 Pizza", Nutrition: 40, Happiness: 15, Energy: 20, WeightGain: 2.0},
-	{Name: "🥗 Salad", Nutrition: 15, Happiness: 3, Energy: 5, WeightGain: 0.2},
-	{Name: "🍔 Burger", Nutrition: 50, Happiness: 20, Energy: 25, WeightGain: 3.0},
-	{Name: "🍦 Ice Cream", Nutrition: 10, Happiness: 25, Energy: 15, WeightGain: 1.5},
-	{Name: "🥕 Carrot", Nutrition: 25, Happiness: 8, Energy: 12, WeightGain: 0.3},
-	{Name: "🍫 Chocolate", Nutrition: 15, Happiness: 30, Energy: 20, WeightGain: 1.0},
-	{Name: "🥩 Steak", Nutrition: 60, Happiness: 10, Energy: 30, WeightGain: 4.0},
+	{Name: "🍎 Apple", Nutrition: 20, Happiness: 5, Energy: 10, WeightGain: 0.5, Price: 3},
+	{Name: "🍕 Pizza", Nutrition: 40, Happiness: 15, Energy: 20, WeightGain: 2.0, Price: 10},
+	{Name: "🥗 Salad", Nutrition: 15, Happiness: 3, Energy: 5, WeightGain: 0.2, Price: 4},
+	{Name: "🍔 Burger", Nutrition: 50, Happiness: 20, Energy: 25, WeightGain: 3.0, Price: 12},
+	{Name: "🍦 Ice Cream", Nutrition: 10, Happiness: 25, Energy: 15, WeightGain: 1.5, Price: 6},
+	{Name: "🥕 Carrot", Nutrition: 25, Happiness: 8, Energy: 12, WeightGain: 0.3, Price: 3},
+	{Name: "🍫 Chocolate", Nutrition: 15, Happiness: 30, Energy: 20, WeightGain: 1.0, Price: 5},
+	{Name: "🥩 Steak", Nutrition: 60, Happiness: 10, Energy: 30, WeightGain: 4.0, Price: 15},
 }
 
 func (a *App) generateFeedList(listFeed *tview.List) {
@@ -36,14 +36,18 @@ func (a *App) generateFeedList(listFeed *tview.List) {
 	listFeed.AddItem("=== AVAILABLE FOOD ===", "", 0, nil)
 	listFeed.AddItem("", "", 0, nil) // Empty line
 
-	for i, food := range availableFoods {
-		foodIndex := i // Capture the index for the closure
+	for _, food := range t.feedableFoods(a.now()) {
+		food := food // Capture the food for the closure
+		stock := fmt.Sprintf("x%d", t.Inventory[food.Name])
+		if t.Inventory[food.Name] <= 0 {
+			stock = "out of stock"
+		}
 		listFeed.AddItem(
-			fmt.Sprintf("%s (Nutrition: %d, Happiness: %d, Energy: %d, Weight: +%.1fg)",
-				food.Name, food.Nutrition, food.Happiness, food.Energy, food.WeightGain),
+			fmt.Sprintf("%s [%s] (Nutrition: %d, Happiness: %d, Energy: %d, Weight: +%.1fg)",
+				food.Name, stock, food.Nutrition, food.Happiness, food.Energy, food.WeightGain),
 			"",
 			0,
-			func() { a.feedTamagotchi(foodIndex) },
+			func() { a.feedTamagotchi(food.Name) },
 		)
 	}
 
@@ -52,31 +56,74 @@ func (a *App) generateFeedList(listFeed *tview.List) {
 	listFeed.AddItem(fmt.Sprintf("Current Hunger: %d/100", t.Hunger), "", 0, nil)
 	listFeed.AddItem(fmt.Sprintf("Current Weight: %.1f grams", t.Weight), "", 0, nil)
 	listFeed.AddItem(fmt.Sprintf("Last Fed: %s", t.LastFed.Format("15:04")), "", 0, nil)
+	listFeed.AddItem("Buy more food in the Shop (Ctrl+B)", "", 0, nil)
 }
 
-func (a *App) feedTamagotchi(foodIndex int) {
-	if foodIndex < 0 || foodIndex >= len(availableFoods) {
-		return
+func (a *App) feedTamagotchi(name string) {
+	a.act(methodFeed, actionParams{Food: name})
+}
+
+// feedPet feeds the named pet, or the current one, a food from its stock.
+func (a *App) feedPet(pet, name string) error {
+	food, ok := findFood(name)
+	if !ok {
+		return fmt.Errorf("unknown food %q", name)
 	}
 
-	food := availableFoods[foodIndex]
-	now := time.Now()
+	now := a.now()
 
 	a.stateMu.Lock()
-	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+	t, err := a.livingPetLocked(pet)
+	if err != nil {
 		a.stateMu.Unlock()
-		return
+		return err
+	}
+
+	if !t.takeItem(food.Name) {
+		a.stateMu.Unlock()
+		return fmt.Errorf("%s is out of stock", food.Name)
 	}
 
-	a.currentTamagotchi.Hunger = max(0, a.currentTamagotchi.Hunger-food.Nutrition)
-	a.currentTamagotchi.Happiness = min(100, a.currentTamagotchi.Happiness+food.Happiness)
-	a.currentTamagotchi.Energy = min(100, a.currentTamagotchi.Energy+food.Energy)
-	a.currentTamagotchi.Weight += food.WeightGain
-	a.currentTamagotchi.LastFed = now
+	t.eat(food, now)
+	pet = t.Name
+	quests := a.questActionLocked(t, questFeed, food.Name, now)
+	reached := a.tasteFoodLocked(t, food.Name, now)
 	a.stateMu.Unlock()
 
 	a.updateConfigFromState()
-	a.addGameEvent("FEED", fmt.Sprintf("Fed %s! Hunger -%d, Happiness +%d", food.Name, food.Nutrition, food.Happiness))
+	a.addPetEvent(pet, "FEED", fmt.Sprintf("Fed %s! Hunger -%d, Happiness +%d", food.Name, food.Nutrition, food.Happiness))
+	a.announceQuests(pet, quests, now)
+	a.announceAchievements(reached, pet, now)
+	return nil
+}
+
+func (t *Tamagotchi) eat(food Food, now time.Time) {
+	t.Hunger = max(0, t.Hunger-food.Nutrition)
+	t.Happiness = min(100, t.Happiness+food.Happiness)
+	t.Energy = min(100, t.Energy+food.Energy)
+	t.Weight += food.WeightGain
+	t.LastFed = now
+}
+
+// feedableFoods lists the foods on offer at the given time, followed by
+// leftovers from past seasons the pet still has.
+func (t Tamagotchi) feedableFoods(at time.Time) []Food {
+	foods := foodCatalog(at)
+	for _, food := range allFoods() {
+		if t.Inventory[food.Name] > 0 && !containsFood(foods, food.Name) {
+			foods = append(foods, food)
+		}
+	}
+	return foods
+}
+
+func containsFood(foods []Food, name string) bool {
+	for _, food := range foods {
+		if food.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (a *App) feedPage() (title string, content tview.Primitive) {
@@ -0,0 +1,286 @@
+package app
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+const (
+	traitCheerful = "cheerful"
+	traitSmart    = "smart"
+	traitStrong   = "strong"
+	traitArtsy    = "artsy"
+	traitChubby   = "chubby"
+
+	maxTraits = 2
+
+	// breedingCooldown is how long a parent rests before breeding again.
+	breedingCooldown = 24 * time.Hour
+	breedingEnergy   = 30
+
+	// Percent chances of a gene mutating instead of being inherited, higher
+	// when only one parent contributes.
+	mutationChance     = 10
+	soloMutationChance = 25
+
+	// inheritedSkillShare is the part of the parents' average skill level a
+	// baby is born with.
+	inheritedSkillShare = 0.25
+)
+
+var geneColors = []string{"white", "gold", "pink", "skyblue", "lightgreen", "orange", "violet", "lightcoral", "aqua"}
+
+var geneTraits = []string{traitCheerful, traitSmart, traitStrong, traitArtsy, traitChubby}
+
+// Genes are fixed at birth and passed on, mixed, to offspring.
+type Genes struct {
+	Color  string // sprite colour
+	Traits []string
+}
+
+// LineageEntry records one member of the family tree. Entries outlive the
+// pets themselves so the tree survives restarts and deaths.
+type LineageEntry struct {
+	ID         string
+	Name       string
+	Parents    []string // IDs
+	Generation int
+	Born       time.Time
+	Color      string
+}
+
+func newPetID() string {
+	b := make([]byte, 8)
+	if _, err := crand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+func randomGenes() Genes {
+	return Genes{
+		Color:  geneColors[randomIntn(len(geneColors))],
+		Traits: []string{geneTraits[randomIntn(len(geneTraits))]},
+	}
+}
+
+// mixGenes builds a baby's genes: each gene comes from a random parent, with
+// a chance to mutate into something new. other may be nil for a solo parent.
+func mixGenes(parent, other *Tamagotchi) Genes {
+	chance := mutationChance
+	parents := []*Tamagotchi{parent}
+	if other != nil {
+		parents = append(parents, other)
+	} else {
+		chance = soloMutationChance
+	}
+
+	var genes Genes
+	genes.Color = parents[randomIntn(len(parents))].Genes.Color
+	if genes.Color == "" || randomIntn(100) < chance {
+		genes.Color = geneColors[randomIntn(len(geneColors))]
+	}
+
+	for _, p := range parents {
+		for _, trait := range p.Genes.Traits {
+			if randomIntn(2) == 0 {
+				genes.addTrait(trait)
+			}
+		}
+	}
+	if randomIntn(100) < chance {
+		genes.addTrait(geneTraits[randomIntn(len(geneTraits))])
+	}
+
+	return genes
+}
+
+func (g *Genes) addTrait(trait string) {
+	if len(g.Traits) >= maxTraits || g.hasTrait(trait) {
+		return
+	}
+	g.Traits = append(g.Traits, trait)
+}
+
+func (g Genes) hasTrait(trait string) bool {
+	for _, t := range g.Traits {
+		if t == trait {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTraits gives a newborn the head start its traits promise.
+func (t *Tamagotchi) applyTraits(now time.Time) {
+	for _, trait := range t.Genes.Traits {
+		switch trait {
+		case traitCheerful:
+			t.Happiness = min(100, t.Happiness+20)
+		case traitSmart:
+			t.trainSkill(skillIntelligence, 10, now)
+		case traitStrong:
+			t.trainSkill(skillStrength, 10, now)
+		case traitArtsy:
+			t.trainSkill(skillCreativity, 10, now)
+		case traitChubby:
+			t.Weight += 10
+		}
+	}
+}
+
+func (t *Tamagotchi) canBreed(now time.Time) bool {
+	return t.IsAlive && t.Stage == "adult" && t.Energy >= breedingEnergy && now.Sub(t.LastBred) >= breedingCooldown
+}
+
+// breedLocked hatches a baby from parent and, optionally, a partner. The baby
+// joins the roster and the family tree.
+func (a *App) breedLocked(parent, partner *Tamagotchi, now time.Time) (*Tamagotchi, error) {
+	if len(a.pets) >= maxPets {
+		return nil, fmt.Errorf("the house is full (%d pets max)", maxPets)
+	}
+	if !parent.canBreed(now) {
+		return nil, fmt.Errorf("%s isn't ready to have a baby", parent.Name)
+	}
+	if partner != nil && (partner == parent || !partner.canBreed(now)) {
+		return nil, fmt.Errorf("%s isn't ready to have a baby", partner.Name)
+	}
+
+	baby := newTamagotchi(a.uniqueNameLocked(), mixGenes(parent, partner), a.newPetBalance(), now)
+	baby.Parents = []string{parent.ID}
+	baby.Generation = parent.Generation + 1
+
+	parents := []*Tamagotchi{parent}
+	if partner != nil {
+		parents = append(parents, partner)
+		baby.Parents = append(baby.Parents, partner.ID)
+		baby.Generation = max(baby.Generation, partner.Generation+1)
+	}
+
+	for _, name := range skillNames {
+		total := 0.0
+		for _, p := range parents {
+			total += p.Skills[name].Level
+		}
+		baby.trainSkill(name, total/float64(len(parents))*inheritedSkillShare, now)
+	}
+
+	for _, p := range parents {
+		p.Energy = max(0, p.Energy-breedingEnergy)
+		p.LastBred = now
+	}
+
+	a.pets = append(a.pets, baby)
+	a.recordLineageLocked(baby)
+	return baby, nil
+}
+
+// breedTamagotchi has the current tamagotchi raise a baby, with the named
+// partner or alone when partnerName is empty.
+func (a *App) breedTamagotchi(partnerName string) {
+	if a.refuseAsClient("BIRTH") {
+		return
+	}
+
+	now := a.now()
+
+	a.stateMu.Lock()
+	parent := a.currentTamagotchi
+	if parent == nil {
+		a.stateMu.Unlock()
+		return
+	}
+
+	var partner *Tamagotchi
+	if partnerName != "" {
+		if partner = a.findPetLocked(partnerName); partner == nil {
+			a.stateMu.Unlock()
+			return
+		}
+	}
+
+	baby, err := a.breedLocked(parent, partner, now)
+	a.stateMu.Unlock()
+
+	if err != nil {
+		a.addGameEvent("BIRTH", fmt.Sprintf("No baby this time: %v", err))
+		return
+	}
+
+	a.updateConfigFromState()
+	if partner != nil {
+		a.addGameEvent("BIRTH", fmt.Sprintf("%s and %s had a baby named %s! 🥚", parent.Name, partner.Name, baby.Name))
+		a.addPetEvent(partner.Name, "BIRTH", fmt.Sprintf("%s and %s had a baby named %s! 🥚", parent.Name, partner.Name, baby.Name))
+	} else {
+		a.addGameEvent("BIRTH", fmt.Sprintf("%s had a baby named %s! 🥚", parent.Name, baby.Name))
+	}
+	a.addPetEvent(baby.Name, "BIRTH", fmt.Sprintf("%s hatched into the family (generation %d, %s)", baby.Name, baby.Generation, baby.Genes.describe()))
+}
+
+func (g Genes) describe() string {
+	if len(g.Traits) == 0 {
+		return g.Color
+	}
+	desc := g.Color
+	for _, trait := range g.Traits {
+		desc += ", " + trait
+	}
+	return desc
+}
+
+func (a *App) recordLineageLocked(t *Tamagotchi) {
+	for _, entry := range a.lineage {
+		if entry.ID == t.ID {
+			return
+		}
+	}
+	a.lineage = append(a.lineage, LineageEntry{
+		ID:         t.ID,
+		Name:       t.Name,
+		Parents:    append([]string(nil), t.Parents...),
+		Generation: t.Generation,
+		Born:       t.Created,
+		Color:      t.Genes.Color,
+	})
+}
+
+func (a *App) lineageSnapshot() []LineageEntry {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+
+	return append([]LineageEntry(nil), a.lineage...)
+}
+
+func lineageFromConfig(entries []config.LineageConfig) []LineageEntry {
+	out := make([]LineageEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, LineageEntry{
+			ID:         e.ID,
+			Name:       e.Name,
+			Parents:    append([]string(nil), e.Parents...),
+			Generation: e.Generation,
+			Born:       e.Born,
+			Color:      e.Color,
+		})
+	}
+	return out
+}
+
+func lineageToConfig(entries []LineageEntry) []config.LineageConfig {
+	out := make([]config.LineageConfig, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, config.LineageConfig{
+			ID:         e.ID,
+			Name:       e.Name,
+			Parents:    append([]string(nil), e.Parents...),
+			Generation: e.Generation,
+			Born:       e.Born,
+			Color:      e.Color,
+		})
+	}
+	return out
+}
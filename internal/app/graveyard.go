@@ -0,0 +1,162 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+const causeReplaced = "replaced"
+
+// notableEventTypes are the events worth remembering on a pet's memorial.
+var notableEventTypes = map[string]bool{
+	"EVOLUTION":   true,
+	"BIRTH":       true,
+	"ADOPT":       true,
+	"ACHIEVEMENT": true,
+	"HOLIDAY":     true,
+	"TAMPER":      true,
+	"CLOCK":       true,
+	"DEATH":       true,
+}
+
+// Peak holds the best stats a tamagotchi ever reached.
+type Peak struct {
+	Happiness int
+	Health    int
+	Energy    int
+	Weight    float64
+	Coins     int
+}
+
+func (t *Tamagotchi) recordPeaks() {
+	t.Peak.Happiness = max(t.Peak.Happiness, t.Happiness)
+	t.Peak.Health = max(t.Peak.Health, t.Health)
+	t.Peak.Energy = max(t.Peak.Energy, t.Energy)
+	t.Peak.Coins = max(t.Peak.Coins, t.Coins)
+	if t.Weight > t.Peak.Weight {
+		t.Peak.Weight = t.Weight
+	}
+}
+
+// causeOfDeath explains what drained a dead tamagotchi's health.
+func causeOfDeath(t *Tamagotchi) string {
+	switch {
+	case t.Hunger > t.Balance.StarvingAt && t.Happiness < t.Balance.MiserableAt:
+		return "starvation and sadness"
+	case t.Hunger > t.Balance.StarvingAt:
+		return "starvation"
+	case t.Happiness < t.Balance.MiserableAt:
+		return "sadness"
+	default:
+		return "poor health"
+	}
+}
+
+// archivePetLocked writes a tamagotchi to the graveyard, keeping its notable
+// events alongside.
+func (a *App) archivePetLocked(t *Tamagotchi, cause string, when time.Time) {
+	t.recordPeaks()
+
+	grave := config.GraveConfig{
+		ID:         t.ID,
+		Name:       t.Name,
+		Born:       t.Created,
+		Died:       when,
+		Stage:      t.Stage,
+		Form:       t.Form,
+		Generation: t.Generation,
+		Cause:      cause,
+		Hardcore:   t.Hardcore,
+		Peak: config.PeakConfig{
+			Happiness: t.Peak.Happiness,
+			Health:    t.Peak.Health,
+			Energy:    t.Peak.Energy,
+			Weight:    t.Peak.Weight,
+			Coins:     t.Peak.Coins,
+		},
+	}
+
+	a.eventsMu.Lock()
+	for _, event := range a.gameEvents[t.Name] {
+		if notableEventTypes[event.Type] {
+			grave.Notable = append(grave.Notable, fmt.Sprintf("%s %s", event.Timestamp.Format("2006-01-02 15:04"), event.Message))
+		}
+	}
+	a.eventsMu.Unlock()
+
+	for _, g := range a.graves {
+		if g.ID == grave.ID {
+			return
+		}
+	}
+	a.graves = append(a.graves, grave)
+
+	if err := config.AddGrave(grave); err != nil {
+		log.Printf("failed to archive %s: %v", t.Name, err)
+	}
+}
+
+func (a *App) gravesSnapshot() []config.GraveConfig {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+
+	return append([]config.GraveConfig(nil), a.graves...)
+}
+
+func (a *App) generateMemorialList(listMemorial *tview.List) {
+	listMemorial.Clear()
+
+	graves := a.gravesSnapshot()
+	if len(graves) == 0 {
+		listMemorial.AddItem("No pets have passed on yet. 🌱", "", 0, nil)
+		return
+	}
+
+	// Most recent first
+	sort.SliceStable(graves, func(i, j int) bool {
+		return graves[i].Died.After(graves[j].Died)
+	})
+
+	listMemorial.AddItem("=== IN LOVING MEMORY ===", "", 0, nil)
+	for _, g := range graves {
+		stage := g.Stage
+		if g.Form != "" {
+			stage = fmt.Sprintf("%s (%s)", g.Stage, g.Form)
+		}
+
+		listMemorial.AddItem("", "", 0, nil) // Empty line
+		mode := ""
+		if g.Hardcore {
+			mode = " ☠️ hardcore"
+		}
+		listMemorial.AddItem(fmt.Sprintf("🪦 %s, %s, generation %d%s", g.Name, stage, g.Generation, mode), "", 0, nil)
+		listMemorial.AddItem(fmt.Sprintf("   %s → %s (lived %s), cause: %s",
+			g.Born.Format("2006-01-02"), g.Died.Format("2006-01-02"), formatDuration(g.Lifespan()), g.Cause), "", 0, nil)
+		listMemorial.AddItem(fmt.Sprintf("   Peak: Happiness %d, Health %d, Energy %d, Weight %.1fg, Coins %d",
+			g.Peak.Happiness, g.Peak.Health, g.Peak.Energy, g.Peak.Weight, g.Peak.Coins), "", 0, nil)
+		for _, note := range g.Notable {
+			listMemorial.AddItem("   • "+note, "", 0, nil)
+		}
+	}
+}
+
+func (a *App) memorialPage() (title string, content tview.Primitive) {
+	listMemorial := a.viewsList["memorial"]
+	if listMemorial == nil {
+		listMemorial = getList()
+		a.viewsList["memorial"] = listMemorial
+	}
+
+	a.generateMemorialList(listMemorial)
+
+	title = memorialSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listMemorial, 0, 1, true), 0, 1, true)
+}
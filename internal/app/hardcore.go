@@ -0,0 +1,88 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+// Hardcore pets are created on the hardcore difficulty. They can't be
+// replaced with Ctrl+R or sent on vacation while alive, and an edit to the
+// save marks them as tampered whatever the integrity settings say.
+
+// flagHardcorePets marks the living hardcore pets as tampered after the save
+// was edited, and logs a TAMPER event for each one newly caught.
+func (a *App) flagHardcorePets(at time.Time) {
+	var caught []string
+	a.stateMu.Lock()
+	for _, t := range a.pets {
+		if !t.Hardcore || !t.IsAlive || t.Tampered {
+			continue
+		}
+		t.Tampered = true
+		caught = append(caught, t.Name)
+	}
+	a.stateMu.Unlock()
+
+	for _, pet := range caught {
+		a.addPetEventAt(pet, "TAMPER", fmt.Sprintf("%s's save was changed outside the game! Its hardcore run no longer counts. ⚠️", pet), at)
+	}
+}
+
+func (a *App) findPetByIDLocked(id string) *Tamagotchi {
+	for _, t := range a.pets {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// modeBadge is shown next to the status of hardcore and tampered pets.
+func modeBadge(t Tamagotchi) string {
+	switch {
+	case t.Hardcore && t.Tampered:
+		return " | ☠️ HARDCORE (tampered)"
+	case t.Hardcore:
+		return " | ☠️ HARDCORE"
+	case t.Tampered:
+		return " | ⚠️ TAMPERED"
+	default:
+		return ""
+	}
+}
+
+// livingHardcorePet returns the name of a living hardcore pet, if any.
+func livingHardcorePet(pets []config.TamagotchiConfig) (string, bool) {
+	for _, p := range pets {
+		if p.Hardcore && p.IsAlive {
+			return p.Name, true
+		}
+	}
+	return "", false
+}
+
+func (a *App) livingHardcorePetLocked() (string, bool) {
+	for _, t := range a.pets {
+		if t.Hardcore && t.IsAlive {
+			return t.Name, true
+		}
+	}
+	return "", false
+}
+
+// showPermadeathModal explains why a hardcore pet can't be replaced.
+func (a *App) showPermadeathModal(pet string) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("☠️ %s is a hardcore pet.\n\nHardcore pets can't be replaced while they are alive.", pet)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.TApp.SetRoot(a.TLayout, true).SetFocus(a.TLayout)
+			a.modal = nil
+		})
+
+	a.modal = modal
+	a.TApp.SetRoot(modal, true).SetFocus(modal)
+}
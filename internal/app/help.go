@@ -1,6 +1,9 @@
 package app
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/rivo/tview"
 )
 
@@ -25,15 +28,70 @@ func (a *App) generateHelpList(listHelp *tview.List) {
 	listHelp.AddItem("🔄 STAGES OF LIFE", "", 0, nil)
 	listHelp.AddItem("Egg → Baby → Child → Teen → Adult", "", 0, nil)
 	listHelp.AddItem("Your tamagotchi evolves based on age.", "", 0, nil)
+	listHelp.AddItem("A skill of 50+ on reaching adulthood unlocks a scholar, athlete or artist", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🧠 SKILLS", "", 0, nil)
+	listHelp.AddItem("Intelligence, strength and creativity grow with related games", "", 0, nil)
+	listHelp.AddItem("Skills untrained for a day slowly fade", "", 0, nil)
+	listHelp.AddItem("Better paid jobs require a minimum skill", "", 0, nil)
 	listHelp.AddItem("", "", 0, nil) // Empty line
 
 	listHelp.AddItem("⚡ GAME MECHANICS", "", 0, nil)
 	listHelp.AddItem("• Stats change automatically over time", "", 0, nil)
 	listHelp.AddItem("• Keep hunger low and happiness high", "", 0, nil)
 	listHelp.AddItem("• Low health can lead to death", "", 0, nil)
+	listHelp.AddItem("• Losing health logs a 🤒 SICK event, and another at 20 or below", "", 0, nil)
+	listHelp.AddItem("• After an hour away, a welcome back summary shows what happened", "", 0, nil)
 	listHelp.AddItem("• Energy is needed for playing", "", 0, nil)
 	listHelp.AddItem("", "", 0, nil) // Empty line
 
+	listHelp.AddItem("⚖️ DIFFICULTY", "", 0, nil)
+	if t, ok := a.tamagotchiSnapshot(); ok {
+		listHelp.AddItem(fmt.Sprintf("%s plays on %s, fixed when it was born:", t.Name, balanceName(t.Balance)), "", 0, nil)
+		for _, line := range describeBalance(t.Balance) {
+			listHelp.AddItem("  "+line, "", 0, nil)
+		}
+	}
+	listHelp.AddItem(fmt.Sprintf("New pets play on %s", balanceName(a.newPetBalance())), "", 0, nil)
+	listHelp.AddItem(fmt.Sprintf("• Presets: %s; pick one with --difficulty", strings.Join(difficulties, ", ")), "", 0, nil)
+	listHelp.AddItem("• Override single values under 'balance' in config.yml", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("☠️ HARDCORE", "", 0, nil)
+	listHelp.AddItem("• Pets born on hardcore can't be replaced with Ctrl+R while alive", "", 0, nil)
+	listHelp.AddItem("• No vacations while a hardcore pet is alive", "", 0, nil)
+	listHelp.AddItem("• Editing the save marks hardcore pets as tampered", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🛡️ FAIR PLAY", "", 0, nil)
+	listHelp.AddItem("• The save is signed; editing pets by hand logs a ⚠️ TAMPER event", "", 0, nil)
+	listHelp.AddItem("• Setting the clock back, or far ahead, logs a 🕰️ CLOCK event", "", 0, nil)
+	listHelp.AddItem("• "+describeIntegrity(a.Config.App.Integrity), "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("💰 ECONOMY", "", 0, nil)
+	listHelp.AddItem("• Food, toys and medicine are bought with coins in the Shop", "", 0, nil)
+	listHelp.AddItem("• Feeding uses up food from your inventory", "", 0, nil)
+	listHelp.AddItem("• Some games need a toy, which is used up when played", "", 0, nil)
+	listHelp.AddItem("• Earn coins from game scores and, as an adult, from jobs", "", 0, nil)
+	listHelp.AddItem("• Durable toys cheer your tamagotchi up just by being owned", "", 0, nil)
+	listHelp.AddItem("• Wear hats, bows and glasses from the Items page", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🐾 MULTIPLE PETS", "", 0, nil)
+	listHelp.AddItem("• Up to 8 pets live together and all of them get hungry over time", "", 0, nil)
+	listHelp.AddItem("• Each pet has its own coins, inventory, skills and event log", "", 0, nil)
+	listHelp.AddItem("• Start with --pet NAME to pick (or adopt) a pet by name", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("👪 BREEDING", "", 0, nil)
+	listHelp.AddItem("• Adults can have a baby alone or with another adult pet", "", 0, nil)
+	listHelp.AddItem("• Babies inherit colour and traits, and a quarter of their parents' skills", "", 0, nil)
+	listHelp.AddItem("• Traits: cheerful, smart, strong, artsy and chubby", "", 0, nil)
+	listHelp.AddItem("• Parents need 30 energy and a day of rest between babies", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
 	listHelp.AddItem("🎯 TIPS FOR SUCCESS", "", 0, nil)
 	listHelp.AddItem("• Feed regularly to prevent hunger", "", 0, nil)
 	listHelp.AddItem("• Play games to increase happiness", "", 0, nil)
@@ -46,6 +104,15 @@ func (a *App) generateHelpList(listHelp *tview.List) {
 	listHelp.AddItem("Ctrl+F: Feed - Give food to tamagotchi", "", 0, nil)
 	listHelp.AddItem("Ctrl+P: Play - Play games with tamagotchi", "", 0, nil)
 	listHelp.AddItem("Ctrl+L: Sleep - Put tamagotchi to sleep", "", 0, nil)
+	listHelp.AddItem("Ctrl+B: Shop - Buy items, use medicine and work", "", 0, nil)
+	listHelp.AddItem("Ctrl+T: Items - Browse inventory and wear accessories", "", 0, nil)
+	listHelp.AddItem("Ctrl+A: Pets - Switch between pets or adopt a new one", "", 0, nil)
+	listHelp.AddItem("Ctrl+N: Next Pet - Switch to the next pet in the roster", "", 0, nil)
+	listHelp.AddItem("Ctrl+G: Family - Have babies and browse the family tree", "", 0, nil)
+	listHelp.AddItem("Ctrl+O: Memorial - Remember pets that passed on", "", 0, nil)
+	listHelp.AddItem("Ctrl+K: Charts - See how stats changed over time", "", 0, nil)
+	listHelp.AddItem("Ctrl+U: Achievements - Browse unlocked and upcoming achievements", "", 0, nil)
+	listHelp.AddItem("Ctrl+V: Vacation - Go on vacation and set up the pet sitter", "", 0, nil)
 	listHelp.AddItem("Ctrl+E: Events - View game history", "", 0, nil)
 	listHelp.AddItem("Ctrl+H: Help - Show this help page", "", 0, nil)
 	listHelp.AddItem("Ctrl+R: Restart - Reset tamagotchi to new egg", "", 0, nil)
@@ -56,20 +123,76 @@ func (a *App) generateHelpList(listHelp *tview.List) {
 	listHelp.AddItem("• Use arrow keys to navigate lists", "", 0, nil)
 	listHelp.AddItem("• Press Enter to select items", "", 0, nil)
 	listHelp.AddItem("• Use Ctrl+key shortcuts for quick access", "", 0, nil)
+	listHelp.AddItem("• On the Events page, Tab moves between the type, range, search", "", 0, nil)
+	listHelp.AddItem("  and date filters; 'Load older events' pages through all history", "", 0, nil)
 	listHelp.AddItem("", "", 0, nil) // Empty line
 
 	listHelp.AddItem("💾 SAVE SYSTEM", "", 0, nil)
 	listHelp.AddItem("Your tamagotchi progress is automatically saved.", "", 0, nil)
 	listHelp.AddItem("Data is stored in your config directory.", "", 0, nil)
+	listHelp.AddItem("Every event is also kept in events.jsonl, so history survives restarts.", "", 0, nil)
+	listHelp.AddItem("Stat history for the Charts page is kept in stats.json.", "", 0, nil)
+	listHelp.AddItem("Run 'termagotchi export --format csv|json' to dump a pet's history,", "", 0, nil)
+	listHelp.AddItem("and 'termagotchi import FILE' to restore it into another save.", "", 0, nil)
 	listHelp.AddItem("", "", 0, nil) // Empty line
 
 	listHelp.AddItem("🔄 RESTART FEATURE", "", 0, nil)
-	listHelp.AddItem("• Use Ctrl+R to restart with a new tamagotchi", "", 0, nil)
+	listHelp.AddItem("• Use Ctrl+R to replace the current pet with a new tamagotchi", "", 0, nil)
 	listHelp.AddItem("• Confirmation modal will ask for your approval", "", 0, nil)
-	listHelp.AddItem("• All progress will be reset to a fresh egg", "", 0, nil)
+	listHelp.AddItem("• The old pet is laid to rest in the Memorial (Ctrl+O)", "", 0, nil)
 	listHelp.AddItem("• Useful if your tamagotchi dies or you want a fresh start", "", 0, nil)
 	listHelp.AddItem("", "", 0, nil) // Empty line
 
+	listHelp.AddItem("🌍 WORLD EVENTS", "", 0, nil)
+	listHelp.AddItem("• Now and then something happens to your pet and you choose what to do", "", 0, nil)
+	listHelp.AddItem("• While you are away a default choice is made and summed up on return", "", 0, nil)
+	listHelp.AddItem("• Put a world_events.yml in the save directory to change the events", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🏖️ VACATION AND PET SITTER", "", 0, nil)
+	listHelp.AddItem("• A vacation freezes or slows every pet's stats for 1 to 14 days", "", 0, nil)
+	listHelp.AddItem("• Up to 4 vacations a year; 'termagotchi vacation' works too", "", 0, nil)
+	listHelp.AddItem("• The pet sitter feeds and beds pets while the game is closed", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🔌 DAEMON", "", 0, nil)
+	listHelp.AddItem("• 'termagotchi daemon' keeps the pets ticking with the game closed", "", 0, nil)
+	listHelp.AddItem("• 'termagotchi serve' does too, with an HTTP API for dashboards", "", 0, nil)
+	if a.remote != nil {
+		listHelp.AddItem("• The daemon is running: only care and vacations work from here", "", 0, nil)
+	}
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🔔 NOTIFICATIONS", "", 0, nil)
+	listHelp.AddItem("• 'termagotchi notify on' alerts you when a pet is hungry, ill or low on health", "", 0, nil)
+	listHelp.AddItem("• Alerts ring the bell, show on the desktop or run a command, outside quiet hours", "", 0, nil)
+	listHelp.AddItem("• 'termagotchi webhook add' posts events to Slack, Discord or any URL", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🎉 SEASONS AND HOLIDAYS", "", 0, nil)
+	listHelp.AddItem("• The sprite is decorated for the season, or for the day's holiday", "", 0, nil)
+	listHelp.AddItem("• Each season brings its own food to the Feed page and the Shop", "", 0, nil)
+	listHelp.AddItem("• New Year, Halloween and your pet's birthday are celebrated once a year", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("📜 QUESTS", "", 0, nil)
+	listHelp.AddItem("• Three daily quests and one weekly quest appear on the Status page", "", 0, nil)
+	listHelp.AddItem("• Feed, play and sleep to make progress; rewards are paid at once", "", 0, nil)
+	listHelp.AddItem("• New quests arrive at midnight, and on Mondays for the weekly one", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🏅 CARE SCORE", "", 0, nil)
+	listHelp.AddItem("• Each day is scored on healthy stats and how fast needs are answered", "", 0, nil)
+	listHelp.AddItem("• Days scoring 60+ build a streak, shown on the Status page", "", 0, nil)
+	listHelp.AddItem("• Yesterday's report opens on the first launch of the day", "", 0, nil)
+	listHelp.AddItem("• Run 'termagotchi report' to print it from the command line", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
+	listHelp.AddItem("🪦 MEMORIAL", "", 0, nil)
+	listHelp.AddItem("• Pets that die or are replaced are archived with their peak stats", "", 0, nil)
+	listHelp.AddItem("• Run 'termagotchi history --sort lifespan' to list them all", "", 0, nil)
+	listHelp.AddItem("", "", 0, nil) // Empty line
+
 	listHelp.AddItem("🐛 TROUBLESHOOTING", "", 0, nil)
 	listHelp.AddItem("• If your tamagotchi dies, use Ctrl+R to restart", "", 0, nil)
 	listHelp.AddItem("• Check the Events page for recent activity", "", 0, nil)
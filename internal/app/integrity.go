@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// What happens to the living pets when the save or the clock were tampered
+// with. Every consequence logs a TAMPER or CLOCK event.
+const (
+	consequenceLog   = "log"   // only the event
+	consequenceFlag  = "flag"  // also marks the pets as tampered, for good
+	consequenceReset = "reset" // also starts their care streaks over
+)
+
+const (
+	// clockTolerance is how far the clock may move back, as when it is
+	// synchronised, before it counts as a jump.
+	clockTolerance = 5 * time.Minute
+
+	// defaultMaxOffline is the longest absence that isn't taken for a
+	// forward clock jump.
+	defaultMaxOffline = 365 * 24 * time.Hour
+)
+
+// checkSaveIntegrity deals with a save that failed its signature check. The
+// integrity settings are signed too and can't be trusted either, so they go
+// back to the defaults. Saving again signs it, so each edit is only caught
+// once.
+func (a *App) checkSaveIntegrity() {
+	if a.Config.SignatureCheck != config.SignatureInvalid {
+		return
+	}
+	a.Config.SignatureCheck = config.SignatureValid
+	a.Config.App.Integrity = config.IntegrityConfig{}
+	a.flagHardcorePets(a.now())
+	a.tampered(a.Config.App.Integrity.OnTamper, "TAMPER",
+		"The save was changed outside the game! ⚠️", a.now())
+}
+
+// checkClockOnLoad looks for clock jumps between the last time the game
+// closed and now. A clock set forward while the game was closed looks like
+// any other absence, unless it is implausibly long; setting it back again
+// afterwards is caught as a backward jump.
+func (a *App) checkClockOnLoad() {
+	last := a.Config.App.LastLogin
+	if last.IsZero() {
+		return
+	}
+
+	maxOffline := a.Config.App.Integrity.MaxOffline
+	if maxOffline <= 0 {
+		maxOffline = defaultMaxOffline
+	}
+
+	elapsed := a.Config.App.CurrentLogin.Sub(last)
+	switch {
+	case elapsed < -clockTolerance:
+		a.tampered(a.Config.App.Integrity.OnClock, "CLOCK",
+			fmt.Sprintf("The clock went back %s since the game last closed! 🕰️", formatDuration(-elapsed)), a.now())
+	case elapsed > maxOffline:
+		a.tampered(a.Config.App.Integrity.OnClock, "CLOCK",
+			fmt.Sprintf("The clock jumped %s ahead since the game last closed! 🕰️", formatDuration(elapsed)), a.now())
+	}
+}
+
+// checkClockJump looks for the wall clock going back between two game loop
+// ticks, by comparing it with the monotonic clock. Forward jumps can't be
+// told apart from the computer sleeping, so only backward ones count.
+func (a *App) checkClockJump(last, now time.Time) {
+	jump := now.Round(0).Sub(last.Round(0)) - now.Sub(last)
+	if jump >= -clockTolerance {
+		return
+	}
+	a.tampered(a.Config.App.Integrity.OnClock, "CLOCK",
+		fmt.Sprintf("The clock went back %s while playing! 🕰️", formatDuration(-jump)), now)
+	a.updateConfigFromState()
+}
+
+// tampered applies the configured consequence to every living pet and logs
+// the event for each of them.
+func (a *App) tampered(consequence, eventType, message string, at time.Time) {
+	switch consequence {
+	case consequenceLog, consequenceFlag, consequenceReset:
+	case "":
+		consequence = consequenceFlag
+	default:
+		log.Printf("unknown integrity consequence %q, using %s", consequence, consequenceFlag)
+		consequence = consequenceFlag
+	}
+
+	var pets []string
+	a.stateMu.Lock()
+	for _, t := range a.pets {
+		if !t.IsAlive {
+			continue
+		}
+		pets = append(pets, t.Name)
+		if consequence != consequenceLog {
+			t.Tampered = true
+		}
+		if consequence == consequenceReset {
+			t.StreakReset = at
+		}
+	}
+	a.stateMu.Unlock()
+
+	for _, pet := range pets {
+		a.addPetEventAt(pet, eventType, message, at)
+	}
+}
+
+// describeIntegrity sums up the integrity settings for the Help page.
+func describeIntegrity(cfg config.IntegrityConfig) string {
+	consequence := func(c string) string {
+		if c == "" {
+			return consequenceFlag
+		}
+		return c
+	}
+	return fmt.Sprintf("Edited saves: %s; clock jumps: %s", consequence(cfg.OnTamper), consequence(cfg.OnClock))
+}
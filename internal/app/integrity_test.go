@@ -0,0 +1,53 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+func TestEditedSaveFlagsHardcorePets(t *testing.T) {
+	clock := newTestClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	a := newTestApp(t, clock)
+	name := setCurrentPet(a, func(t *Tamagotchi) {
+		t.Hardcore = true
+		t.Coins = 10
+	})
+	a.save()
+
+	dir, err := config.Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.yml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), "coins: 10", "coins: 9999", 1)
+	if edited == string(data) {
+		t.Fatal("no coins to edit in the save")
+	}
+	if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := newApp(cfg, clock.now)
+	b.initializeStateFromConfig()
+	t.Cleanup(b.closeJournal)
+
+	b.stateMu.RLock()
+	tampered := b.findPetLocked(name).Tampered
+	b.stateMu.RUnlock()
+	if !tampered {
+		t.Errorf("%s wasn't marked as tampered", name)
+	}
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	slotHead = "head"
+	slotEar  = "ear"
+	slotEyes = "eyes"
+
+	// toyComfortCap is the happiness level up to which owned toys keep
+	// cheering the tamagotchi up on their own.
+	toyComfortCap = 60
+)
+
+func copyEquipped(equipped map[string]string) map[string]string {
+	if equipped == nil {
+		return nil
+	}
+	eq := make(map[string]string, len(equipped))
+	for slot, name := range equipped {
+		eq[slot] = name
+	}
+	return eq
+}
+
+// toyComfort is the passive happiness per tick granted by owned durable toys.
+func (t *Tamagotchi) toyComfort() int {
+	comfort := 0
+	for name, qty := range t.Inventory {
+		if qty <= 0 {
+			continue
+		}
+		if item, ok := findItem(name); ok && item.Durable && item.Kind == itemKindToy {
+			comfort += item.Comfort
+		}
+	}
+	return comfort
+}
+
+// equippedItems returns the accessories being worn, ordered by slot so the
+// sprite is always composed the same way.
+func (t Tamagotchi) equippedItems() []Item {
+	slots := make([]string, 0, len(t.Equipped))
+	for slot := range t.Equipped {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+
+	items := make([]Item, 0, len(slots))
+	for _, slot := range slots {
+		if item, ok := findItem(t.Equipped[slot]); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (a *App) generateItemsList(listItems *tview.List) {
+	listItems.Clear()
+
+	t, ok := a.tamagotchiSnapshot()
+	if !ok {
+		listItems.AddItem("No tamagotchi available.", "", 0, nil)
+		return
+	}
+
+	names := make([]string, 0, len(t.Inventory))
+	for name := range t.Inventory {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	listItems.AddItem("=== ACCESSORIES ===", "", 0, nil)
+	hasAccessories := false
+	for _, name := range names {
+		item, ok := findItem(name)
+		if !ok || item.Kind != itemKindAccessory {
+			continue
+		}
+		hasAccessories = true
+		action := "Wear"
+		if t.Equipped[item.Slot] == item.Name {
+			action = "Take off"
+		}
+		listItems.AddItem(
+			fmt.Sprintf("%s %s (%s)", action, item.Name, item.Slot),
+			"",
+			0,
+			func() { a.toggleAccessory(item) },
+		)
+	}
+	if !hasAccessories {
+		listItems.AddItem("No accessories yet. Hats, bows and glasses are sold in the Shop.", "", 0, nil)
+	}
+
+	listItems.AddItem("", "", 0, nil) // Empty line
+	listItems.AddItem("=== TOYS ===", "", 0, nil)
+	hasToys := false
+	for _, name := range names {
+		item, ok := findItem(name)
+		if !ok || item.Kind != itemKindToy {
+			continue
+		}
+		hasToys = true
+		if item.Durable {
+			listItems.AddItem(fmt.Sprintf("%s (+%d happiness while under %d)", item.Name, item.Comfort, toyComfortCap), "", 0, nil)
+		} else {
+			listItems.AddItem(fmt.Sprintf("%s x%d (used up by games)", item.Name, t.Inventory[name]), "", 0, nil)
+		}
+	}
+	if !hasToys {
+		listItems.AddItem("No toys yet.", "", 0, nil)
+	}
+
+	listItems.AddItem("", "", 0, nil) // Empty line
+	listItems.AddItem("=== SUPPLIES ===", "", 0, nil)
+	hasSupplies := false
+	for _, name := range names {
+		item, ok := findItem(name)
+		if ok && item.Kind != itemKindFood && item.Kind != itemKindMedicine {
+			continue
+		}
+		hasSupplies = true
+		listItems.AddItem(fmt.Sprintf("%s x%d", name, t.Inventory[name]), "", 0, nil)
+	}
+	if !hasSupplies {
+		listItems.AddItem("No food or medicine left.", "", 0, nil)
+	}
+}
+
+func (a *App) toggleAccessory(item Item) {
+	if a.refuseAsClient("ITEM") {
+		return
+	}
+
+	a.stateMu.Lock()
+	if a.currentTamagotchi == nil || a.currentTamagotchi.Inventory[item.Name] <= 0 {
+		a.stateMu.Unlock()
+		return
+	}
+
+	t := a.currentTamagotchi
+	message := fmt.Sprintf("Put on %s", item.Name)
+	if t.Equipped[item.Slot] == item.Name {
+		delete(t.Equipped, item.Slot)
+		message = fmt.Sprintf("Took off %s", item.Name)
+	} else {
+		if t.Equipped == nil {
+			t.Equipped = make(map[string]string)
+		}
+		t.Equipped[item.Slot] = item.Name
+	}
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.addGameEvent("ITEM", message)
+}
+
+func (a *App) itemsPage() (title string, content tview.Primitive) {
+	listItems := a.viewsList["items"]
+	if listItems == nil {
+		listItems = getList()
+		a.viewsList["items"] = listItems
+	}
+
+	a.generateItemsList(listItems)
+
+	title = itemsSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listItems, 0, 1, true), 0, 1, true)
+}
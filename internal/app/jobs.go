@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+)
+
+type Job struct {
+	Name      string
+	Pay       int
+	Energy    int
+	Happiness int
+	Hunger    int
+	Skill     string // skill the job requires, if any
+	MinSkill  int
+}
+
+var availableJobs = []Job{
+	{Name: "🧹 Office Cleaner", Pay: 15, Energy: -20, Happiness: -5, Hunger: 10},
+	{Name: "☕ Barista", Pay: 20, Energy: -25, Happiness: -3, Hunger: 15},
+	{Name: "📦 Courier", Pay: 25, Energy: -35, Happiness: -5, Hunger: 20, Skill: skillStrength, MinSkill: 30},
+	{Name: "💻 Programmer", Pay: 35, Energy: -30, Happiness: -15, Hunger: 15, Skill: skillIntelligence, MinSkill: 40},
+	{Name: "🖼️ Illustrator", Pay: 30, Energy: -20, Happiness: -5, Hunger: 10, Skill: skillCreativity, MinSkill: 40},
+}
+
+func (a *App) workJob(jobIndex int) {
+	if jobIndex < 0 || jobIndex >= len(availableJobs) || a.refuseAsClient("WORK") {
+		return
+	}
+
+	job := availableJobs[jobIndex]
+	now := a.now()
+
+	a.stateMu.Lock()
+	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+		a.stateMu.Unlock()
+		return
+	}
+
+	if a.currentTamagotchi.Stage != "adult" || a.currentTamagotchi.Energy < -job.Energy {
+		a.stateMu.Unlock()
+		return
+	}
+
+	if !a.currentTamagotchi.qualifiesFor(job) {
+		a.stateMu.Unlock()
+		return
+	}
+
+	a.currentTamagotchi.Coins += job.Pay
+	a.currentTamagotchi.Energy = max(0, a.currentTamagotchi.Energy+job.Energy)
+	a.currentTamagotchi.Happiness = max(0, a.currentTamagotchi.Happiness+job.Happiness)
+	a.currentTamagotchi.Hunger = min(100, a.currentTamagotchi.Hunger+job.Hunger)
+	a.currentTamagotchi.LastPlay = now
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.addGameEvent("WORK", fmt.Sprintf("Worked as %s! Earned %d coins", job.Name, job.Pay))
+}
+
+func (t *Tamagotchi) qualifiesFor(job Job) bool {
+	return job.Skill == "" || t.skillLevel(job.Skill) >= job.MinSkill
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"log"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// openJournal opens the on-disk event journal and replays its tail into the
+// in-memory event logs of the pets on the roster, so it is called once they
+// are loaded. Without a journal events are simply not persisted.
+func (a *App) openJournal() {
+	dir, ok := a.replayJournal()
+	if !ok {
+		return
+	}
+
+	journal, err := config.OpenJournal(dir)
+	if err != nil {
+		log.Printf("failed to open event journal: %v", err)
+		return
+	}
+	a.journal = journal
+}
+
+// replayJournal replays the tail of the event journal into the in-memory
+// event logs and returns the journal's directory. It reads back from the
+// newest file until every pet's log is full, or the files left are older than
+// every pet.
+func (a *App) replayJournal() (string, bool) {
+	dir, err := config.Dir()
+	if err != nil {
+		log.Printf("failed to open event journal: %v", err)
+		return "", false
+	}
+
+	counts := make(map[string]int, len(a.pets))
+	var born time.Time
+	for _, t := range a.pets {
+		counts[t.Name] = 0
+		if born.IsZero() || t.Created.Before(born) {
+			born = t.Created
+		}
+	}
+
+	var files [][]config.EventRecord
+	err = config.ReadJournalNewestFirst(dir, func(records []config.EventRecord) bool {
+		files = append(files, records)
+		for _, rec := range records {
+			if _, ok := counts[rec.Pet]; ok {
+				counts[rec.Pet]++
+			}
+		}
+		if len(records) > 0 && records[0].Timestamp.Before(born) {
+			return false
+		}
+		for _, n := range counts {
+			if n < maxRecentEvents {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		log.Printf("failed to read part of the event journal: %v", err)
+	}
+
+	a.eventsMu.Lock()
+	for i := len(files) - 1; i >= 0; i-- {
+		for _, rec := range files[i] {
+			a.pushEventLocked(eventFromRecord(rec))
+		}
+	}
+	a.eventsMu.Unlock()
+	return dir, true
+}
+
+func (a *App) writeJournal(event GameEvent) {
+	if a.journal == nil {
+		return
+	}
+	if err := a.journal.Append(eventToRecord(event)); err != nil {
+		log.Printf("failed to write event journal: %v", err)
+	}
+}
+
+func eventFromRecord(rec config.EventRecord) GameEvent {
+	return GameEvent{
+		Pet:       rec.Pet,
+		Type:      rec.Type,
+		Message:   rec.Message,
+		Timestamp: rec.Timestamp,
+	}
+}
+
+func eventToRecord(event GameEvent) config.EventRecord {
+	return config.EventRecord{
+		Pet:       event.Pet,
+		Type:      event.Type,
+		Message:   event.Message,
+		Timestamp: event.Timestamp,
+	}
+}
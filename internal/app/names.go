@@ -32,10 +32,18 @@ func randomName() string {
 	if len(tamagotchiNames) == 0 {
 		return "Olaf"
 	}
-	upper := big.NewInt(int64(len(tamagotchiNames)))
-	n, err := crand.Int(crand.Reader, upper)
+	return tamagotchiNames[randomIntn(len(tamagotchiNames))]
+}
+
+// randomIntn returns a random number in [0, n), or 0 if the system's random
+// source fails.
+func randomIntn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	v, err := crand.Int(crand.Reader, big.NewInt(int64(n)))
 	if err != nil {
-		return tamagotchiNames[0]
+		return 0
 	}
-	return tamagotchiNames[n.Int64()]
+	return int(v.Int64())
 }
@@ -0,0 +1,315 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// The notifier alerts the player when a pet needs attention, while the daemon
+// or the game is running. It logs an ALERT event when a pet gets hungry or its
+// health runs low, and passes those on through the player's backends along
+// with pets falling ill and dying, unless it is quiet hours or the same alert
+// went out recently.
+
+const (
+	notifyBell    = "bell"
+	notifyDesktop = "desktop"
+	notifyCommand = "command"
+
+	defaultNotifyHungerAt = 80
+	defaultNotifyHealthAt = 50
+	defaultNotifyEvery    = 30 * time.Minute
+
+	// notifyCheckInterval is how often the pets' stats are checked.
+	notifyCheckInterval = 10 * time.Second
+
+	// notifyTimeout is how long the backends have to deliver an alert.
+	notifyTimeout = 10 * time.Second
+
+	// quietHoursLayout is how quiet hours are written.
+	quietHoursLayout = "15:04"
+)
+
+// Kinds of alert. Each is rate limited on its own.
+const (
+	alertHunger = "hunger"
+	alertHealth = "health"
+	alertSick   = "sick"
+	alertDeath  = "death"
+)
+
+var notifyBackends = []string{notifyBell, notifyDesktop, notifyCommand}
+
+// eventAlerts are the events passed on as alerts, with their kind.
+var eventAlerts = map[string]string{
+	"SICK":  alertSick,
+	"DEATH": alertDeath,
+}
+
+type alert struct {
+	Pet     string
+	Kind    string
+	Message string
+}
+
+// A notifier delivers alerts where the player will see them.
+type notifier interface {
+	notify(ctx context.Context, al alert) error
+}
+
+// bellNotifier rings the terminal bell.
+type bellNotifier struct{}
+
+func (bellNotifier) notify(ctx context.Context, al alert) error {
+	_, err := os.Stdout.WriteString("\a")
+	return err
+}
+
+// desktopNotifier shows a desktop notification with notify-send.
+type desktopNotifier struct{}
+
+func (desktopNotifier) notify(ctx context.Context, al alert) error {
+	return exec.CommandContext(ctx, "notify-send", "--app-name=Termagotchi", "Termagotchi: "+al.Pet, al.Message).Run()
+}
+
+// commandNotifier runs a shell command with the alert in its environment.
+type commandNotifier struct {
+	command string
+}
+
+func (n commandNotifier) notify(ctx context.Context, al alert) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", n.command)
+	cmd.Env = append(os.Environ(),
+		"TERMAGOTCHI_PET="+al.Pet,
+		"TERMAGOTCHI_ALERT="+al.Kind,
+		"TERMAGOTCHI_MESSAGE="+al.Message,
+	)
+	return cmd.Run()
+}
+
+func newNotifier(backend string, s config.NotifyConfig) (notifier, error) {
+	switch backend {
+	case notifyBell:
+		return bellNotifier{}, nil
+	case notifyDesktop:
+		return desktopNotifier{}, nil
+	case notifyCommand:
+		return commandNotifier{command: s.Command}, nil
+	}
+	return nil, fmt.Errorf("unknown backend %q", backend)
+}
+
+// WithNotifyDefaults fills in the notifier settings left unset.
+func WithNotifyDefaults(s config.NotifyConfig) config.NotifyConfig {
+	if len(s.Backends) == 0 {
+		s.Backends = []string{notifyBell}
+	}
+	if s.HungerAt == 0 {
+		s.HungerAt = defaultNotifyHungerAt
+	}
+	if s.HealthAt == 0 {
+		s.HealthAt = defaultNotifyHealthAt
+	}
+	if s.Every == 0 {
+		s.Every = defaultNotifyEvery
+	}
+	return s
+}
+
+// ValidateNotify checks notifier settings given by the player.
+func ValidateNotify(s config.NotifyConfig) error {
+	for _, backend := range s.Backends {
+		if !slices.Contains(notifyBackends, backend) {
+			return fmt.Errorf("unknown backend %q, want %s", backend, strings.Join(notifyBackends, ", "))
+		}
+	}
+	if slices.Contains(s.Backends, notifyCommand) && s.Command == "" {
+		return errors.New("the command backend needs a command")
+	}
+	if s.HungerAt < 1 || s.HungerAt > 100 || s.HealthAt < 1 || s.HealthAt > 100 {
+		return errors.New("notification thresholds must be between 1 and 100")
+	}
+	if s.Every < 0 {
+		return errors.New("the time between alerts can't be negative")
+	}
+	if (s.QuietFrom == "") != (s.QuietUntil == "") {
+		return errors.New("quiet hours need both a start and an end")
+	}
+	for _, at := range []string{s.QuietFrom, s.QuietUntil} {
+		if _, err := time.Parse(quietHoursLayout, at); at != "" && err != nil {
+			return fmt.Errorf("quiet hours are times of day like 22:00, not %q", at)
+		}
+	}
+	return nil
+}
+
+// DescribeNotify sums up the notifier's settings.
+func DescribeNotify(s config.NotifyConfig) string {
+	if !s.Enabled {
+		return "Notifications: off"
+	}
+	quiet := ""
+	if s.QuietFrom != "" {
+		quiet = fmt.Sprintf(", quiet from %s to %s", s.QuietFrom, s.QuietUntil)
+	}
+	return fmt.Sprintf("Notifications: on through %s, at hunger %d and health %d, the same alert at most every %s%s",
+		strings.Join(s.Backends, ", "), s.HungerAt, s.HealthAt, formatDuration(s.Every), quiet)
+}
+
+// SendTestNotification sends a test alert through the backends in s, quiet
+// hours or not.
+func SendTestNotification(s config.NotifyConfig) error {
+	return deliver(WithNotifyDefaults(s), alert{Pet: "Termagotchi", Kind: "test", Message: "Notifications are working. 🔔"})
+}
+
+// inQuietHours reports whether the time of day is within quiet hours, which
+// may run past midnight.
+func inQuietHours(s config.NotifyConfig, at time.Time) bool {
+	from, err := time.Parse(quietHoursLayout, s.QuietFrom)
+	if err != nil {
+		return false
+	}
+	until, err := time.Parse(quietHoursLayout, s.QuietUntil)
+	if err != nil {
+		return false
+	}
+	minute := at.Hour()*60 + at.Minute()
+	start := from.Hour()*60 + from.Minute()
+	end := until.Hour()*60 + until.Minute()
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// deliver sends an alert through every backend in s.
+func deliver(s config.NotifyConfig, al alert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, backend := range s.Backends {
+		n, err := newNotifier(backend, s)
+		if err == nil {
+			err = n.notify(ctx, al)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", backend, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// alertWatch is what the notifier remembers between checks.
+type alertWatch struct {
+	logged  map[string]bool      // pet ID and kind -> past the threshold, ALERT event logged
+	alerted map[string]bool      // pet ID and kind -> past the threshold, alert sent
+	sent    map[string]time.Time // pet and kind -> when the last alert went out
+}
+
+func newAlertWatch() *alertWatch {
+	return &alertWatch{logged: make(map[string]bool), alerted: make(map[string]bool), sent: make(map[string]time.Time)}
+}
+
+// watchPets runs the notifier for as long as the game runs.
+func (a *App) watchPets() {
+	events, cancel := a.subscribe("")
+	defer cancel()
+	ticker := time.NewTicker(notifyCheckInterval)
+	defer ticker.Stop()
+
+	w := newAlertWatch()
+	a.checkPets(w)
+	for {
+		select {
+		case event := <-events:
+			if kind, ok := eventAlerts[event.Type]; ok {
+				a.sendAlert(w, alert{Pet: event.Pet, Kind: kind, Message: event.Message})
+			}
+		case <-ticker.C:
+			a.checkPets(w)
+		}
+	}
+}
+
+func (a *App) notifySettings() config.NotifyConfig {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+	return WithNotifyDefaults(a.notify)
+}
+
+// checkPets alerts once for each pet that got hungry or whose health ran low.
+// An alert held back by quiet hours or the rate limit goes out at the first
+// check after, if the pet still needs it. The ALERT events are logged straight
+// away, for webhooks that want them even with notifications off.
+func (a *App) checkPets(w *alertWatch) {
+	s := a.notifySettings()
+	if !s.Enabled && !a.webhooksWant("ALERT") {
+		return
+	}
+
+	for _, t := range a.petsSnapshot() {
+		if !t.IsAlive {
+			continue
+		}
+		checks := []struct {
+			kind    string
+			past    bool
+			message string
+		}{
+			{alertHunger, t.Hunger >= s.HungerAt, fmt.Sprintf("%s is hungry! Hunger is up to %d. 🍽️", t.Name, t.Hunger)},
+			{alertHealth, t.Health <= s.HealthAt, fmt.Sprintf("%s needs looking after! Health is down to %d. 🚑", t.Name, t.Health)},
+		}
+		for _, c := range checks {
+			key := t.ID + "/" + c.kind
+			if !c.past {
+				delete(w.logged, key)
+				delete(w.alerted, key)
+				continue
+			}
+			if !w.logged[key] {
+				w.logged[key] = true
+				a.addPetEvent(t.Name, "ALERT", c.message)
+			}
+			if !w.alerted[key] {
+				w.alerted[key] = a.sendAlert(w, alert{Pet: t.Name, Kind: c.kind, Message: c.message})
+			}
+		}
+	}
+}
+
+// sendAlert passes an alert on, unless notifications are off, it is quiet
+// hours or the same alert went out less than the settings' Every ago, and
+// reports whether it went out.
+func (a *App) sendAlert(w *alertWatch, al alert) bool {
+	s := a.notifySettings()
+	now := a.now()
+	key := al.Pet + "/" + al.Kind
+	if !s.Enabled || inQuietHours(s, now) || now.Sub(w.sent[key]) < s.Every {
+		return false
+	}
+	w.sent[key] = now
+
+	if err := deliver(s, al); err != nil {
+		log.Printf("failed to send alert: %v", err)
+	}
+	return true
+}
+
+// updateNotify changes the notifier's settings.
+func (a *App) updateNotify(s config.NotifyConfig) {
+	a.stateMu.Lock()
+	a.notify = s
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+}
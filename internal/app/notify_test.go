@@ -0,0 +1,84 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+func TestInQuietHours(t *testing.T) {
+	tests := []struct {
+		from, until string
+		hour, min   int
+		want        bool
+	}{
+		{"22:00", "07:00", 21, 59, false},
+		{"22:00", "07:00", 22, 0, true},
+		{"22:00", "07:00", 3, 0, true},
+		{"22:00", "07:00", 7, 0, false},
+		{"13:00", "14:30", 14, 29, true},
+		{"13:00", "14:30", 14, 30, false},
+		{"", "", 3, 0, false},
+	}
+	for _, tt := range tests {
+		s := config.NotifyConfig{QuietFrom: tt.from, QuietUntil: tt.until}
+		at := time.Date(2026, 3, 1, tt.hour, tt.min, 0, 0, time.Local)
+		if got := inQuietHours(s, at); got != tt.want {
+			t.Errorf("inQuietHours(%s-%s, %02d:%02d) = %v, want %v", tt.from, tt.until, tt.hour, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestAlertHeldBackByQuietHours(t *testing.T) {
+	clock := newTestClock(time.Now())
+	a := newTestApp(t, clock)
+
+	out := filepath.Join(t.TempDir(), "alerts")
+	a.updateNotify(WithNotifyDefaults(config.NotifyConfig{
+		Enabled:    true,
+		Backends:   []string{notifyCommand},
+		Command:    `echo "$TERMAGOTCHI_ALERT" >> ` + out,
+		QuietFrom:  "22:00",
+		QuietUntil: "07:00",
+	}))
+	setCurrentPet(a, func(t *Tamagotchi) { t.Hunger = 90 })
+
+	alerts := func() []string {
+		b, _ := os.ReadFile(out)
+		return strings.Fields(string(b))
+	}
+	logged := func() int {
+		n := 0
+		for _, e := range a.eventsSnapshot() {
+			if e.Type == "ALERT" {
+				n++
+			}
+		}
+		return n
+	}
+
+	w := newAlertWatch()
+	day := time.Now()
+	clock.set(time.Date(day.Year(), day.Month(), day.Day(), 23, 0, 0, 0, time.Local))
+	a.checkPets(w)
+	if got := alerts(); len(got) != 0 {
+		t.Fatalf("alerts during quiet hours: %v", got)
+	}
+	if logged() != 1 {
+		t.Fatalf("ALERT events = %d, want 1", logged())
+	}
+
+	clock.set(clock.now().Add(8*time.Hour + 30*time.Minute))
+	a.checkPets(w)
+	a.checkPets(w)
+	if got := alerts(); len(got) != 1 || got[0] != alertHunger {
+		t.Fatalf("alerts after quiet hours = %v, want [%s]", got, alertHunger)
+	}
+	if logged() != 1 {
+		t.Fatalf("ALERT events = %d, want still 1", logged())
+	}
+}
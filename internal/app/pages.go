@@ -6,12 +6,20 @@ import (
 )
 
 const (
-	statusSection = "Status"
-	feedSection   = "Feed"
-	playSection   = "Play"
-	sleepSection  = "Sleep"
-	eventsSection = "Events"
-	helpSection   = "Help"
+	statusSection       = "Status"
+	feedSection         = "Feed"
+	playSection         = "Play"
+	sleepSection        = "Sleep"
+	eventsSection       = "Events"
+	shopSection         = "Shop"
+	itemsSection        = "Items"
+	petsSection         = "Pets"
+	familySection       = "Family"
+	memorialSection     = "Memorial"
+	chartsSection       = "Charts"
+	achievementsSection = "Achievements"
+	vacationSection     = "Vacation"
+	helpSection         = "Help"
 )
 
 func (a *App) getPagesInfo() (tview.Primitive, tview.Primitive) {
@@ -30,6 +38,30 @@ func (a *App) getPagesInfo() (tview.Primitive, tview.Primitive) {
 	_, sleepContent := a.sleepPage()
 	pages.AddPage(sleepSection, sleepContent, true, false)
 
+	_, shopContent := a.shopPage()
+	pages.AddPage(shopSection, shopContent, true, false)
+
+	_, itemsContent := a.itemsPage()
+	pages.AddPage(itemsSection, itemsContent, true, false)
+
+	_, petsContent := a.petsPage()
+	pages.AddPage(petsSection, petsContent, true, false)
+
+	_, familyContent := a.familyPage()
+	pages.AddPage(familySection, familyContent, true, false)
+
+	_, memorialContent := a.memorialPage()
+	pages.AddPage(memorialSection, memorialContent, true, false)
+
+	_, chartsContent := a.chartsPage()
+	pages.AddPage(chartsSection, chartsContent, true, false)
+
+	_, achievementsContent := a.achievementsPage()
+	pages.AddPage(achievementsSection, achievementsContent, true, false)
+
+	_, vacationContent := a.vacationPage()
+	pages.AddPage(vacationSection, vacationContent, true, false)
+
 	_, eventsContent := a.eventsPage()
 	pages.AddPage(eventsSection, eventsContent, true, false)
 
@@ -39,7 +71,7 @@ func (a *App) getPagesInfo() (tview.Primitive, tview.Primitive) {
 	// Info bar
 	info := tview.NewTextView().
 		SetDynamicColors(true).
-		SetText("Ctrl+S: Status | Ctrl+F: Feed | Ctrl+P: Play | Ctrl+L: Sleep | Ctrl+E: Events | Ctrl+H: Help | Ctrl+R: Restart | Ctrl+C: Quit")
+		SetText("Ctrl+S: Status | Ctrl+F: Feed | Ctrl+P: Play | Ctrl+L: Sleep | Ctrl+B: Shop | Ctrl+T: Items | Ctrl+A: Pets | Ctrl+N: Next Pet | Ctrl+G: Family | Ctrl+O: Memorial | Ctrl+K: Charts | Ctrl+U: Achievements | Ctrl+V: Vacation | Ctrl+E: Events | Ctrl+H: Help | Ctrl+R: Restart | Ctrl+C: Quit")
 
 	return pages, info
 }
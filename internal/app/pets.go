@@ -0,0 +1,214 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+// maxPets caps how many tamagotchis can live in one install.
+const maxPets = 8
+
+// AddImportedPet adds a pet restored from an export to the roster in cfg
+// without starting the game, and makes it the active pet.
+func AddImportedPet(cfg *config.Config, pet config.TamagotchiConfig, now time.Time) error {
+	for _, p := range cfg.Pets {
+		if p.ID == pet.ID || p.Name == pet.Name {
+			return fmt.Errorf("%s is already in this save", pet.Name)
+		}
+	}
+	if len(cfg.Pets) >= maxPets {
+		return fmt.Errorf("the house is full (%d pets max)", maxPets)
+	}
+
+	// A save never played gets a new egg instead of the pets it has, so the
+	// import counts as its first login.
+	if cfg.App.LastLogin.IsZero() {
+		cfg.App.LastLogin = now
+		cfg.App.CurrentLogin = now
+	}
+	cfg.Pets = append(cfg.Pets, pet)
+	cfg.App.ActivePet = pet.Name
+	return nil
+}
+
+func (a *App) findPetLocked(name string) *Tamagotchi {
+	if name == "" {
+		return nil
+	}
+	for _, t := range a.pets {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// livingPetLocked finds the named pet, or the current one when no name is
+// given, as long as it is alive.
+func (a *App) livingPetLocked(name string) (*Tamagotchi, error) {
+	t := a.currentTamagotchi
+	if name != "" {
+		t = a.findPetLocked(name)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("no pet named %q", name)
+	}
+	if !t.IsAlive {
+		return nil, fmt.Errorf("%s has passed away", t.Name)
+	}
+	return t, nil
+}
+
+func (a *App) anyAliveLocked() bool {
+	for _, t := range a.pets {
+		if t.IsAlive {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueNameLocked picks a random name that no pet, past or present, has
+// used yet, numbering it when every name is taken. Event logs are keyed by
+// name, so reusing one would mix two pets' histories.
+func (a *App) uniqueNameLocked() string {
+	for i := 0; i < len(tamagotchiNames)*2; i++ {
+		if name := randomName(); !a.nameTakenLocked(name) {
+			return name
+		}
+	}
+
+	base := randomName()
+	for n := 2; ; n++ {
+		if name := fmt.Sprintf("%s %d", base, n); !a.nameTakenLocked(name) {
+			return name
+		}
+	}
+}
+
+func (a *App) nameTakenLocked(name string) bool {
+	if a.findPetLocked(name) != nil {
+		return true
+	}
+	for _, entry := range a.lineage {
+		if entry.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) petsSnapshot() []Tamagotchi {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+
+	pets := make([]Tamagotchi, 0, len(a.pets))
+	for _, t := range a.pets {
+		pets = append(pets, t.clone())
+	}
+	return pets
+}
+
+func (a *App) switchToPet(name string) {
+	a.stateMu.Lock()
+	t := a.findPetLocked(name)
+	if t == nil || t == a.currentTamagotchi {
+		a.stateMu.Unlock()
+		return
+	}
+	a.currentTamagotchi = t
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.requestRefresh()
+}
+
+func (a *App) switchToNextPet() {
+	a.stateMu.RLock()
+	next := ""
+	for i, t := range a.pets {
+		if t == a.currentTamagotchi {
+			next = a.pets[(i+1)%len(a.pets)].Name
+			break
+		}
+	}
+	a.stateMu.RUnlock()
+
+	a.switchToPet(next)
+}
+
+func (a *App) adoptPet() {
+	if a.refuseAsClient("ADOPT") {
+		return
+	}
+
+	a.stateMu.Lock()
+	if len(a.pets) >= maxPets {
+		a.stateMu.Unlock()
+		return
+	}
+	t := newDefaultTamagotchi(a.uniqueNameLocked(), a.newPetBalance(), a.now())
+	a.pets = append(a.pets, t)
+	a.recordLineageLocked(t)
+	a.currentTamagotchi = t
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.addGameEvent("ADOPT", fmt.Sprintf("Adopted a new tamagotchi named %s! 🥚", t.Name))
+}
+
+func (a *App) generatePetsList(listPets *tview.List) {
+	listPets.Clear()
+
+	current, _ := a.tamagotchiSnapshot()
+	pets := a.petsSnapshot()
+
+	listPets.AddItem("=== YOUR PETS ===", "", 0, nil)
+	listPets.AddItem("", "", 0, nil) // Empty line
+
+	for _, t := range pets {
+		name := t.Name // Capture the name for the closure
+		marker := "  "
+		if name == current.Name {
+			marker = "▶ "
+		}
+		status := "🟢"
+		if !t.IsAlive {
+			status = "🔴"
+		}
+		listPets.AddItem(
+			fmt.Sprintf("%s%s %s (%s, Hunger: %d, Happiness: %d, Health: %d, Energy: %d)",
+				marker, status, t.Name, t.Stage, t.Hunger, t.Happiness, t.Health, t.Energy),
+			"",
+			0,
+			func() { a.switchToPet(name) },
+		)
+	}
+
+	listPets.AddItem("", "", 0, nil) // Empty line
+	if len(pets) < maxPets {
+		listPets.AddItem("🥚 Adopt a new egg", "", 0, a.adoptPet)
+	} else {
+		listPets.AddItem(fmt.Sprintf("The house is full (%d pets max)", maxPets), "", 0, nil)
+	}
+	listPets.AddItem("Ctrl+N switches to the next pet from any page", "", 0, nil)
+}
+
+func (a *App) petsPage() (title string, content tview.Primitive) {
+	listPets := a.viewsList["pets"]
+	if listPets == nil {
+		listPets = getList()
+		a.viewsList["pets"] = listPets
+	}
+
+	a.generatePetsList(listPets)
+
+	title = petsSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listPets, 0, 1, true), 0, 1, true)
+}
@@ -2,28 +2,30 @@ package app
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/rivo/tview"
 )
 
 type Game struct {
-	Name       string
-	Happiness  int
-	Energy     int
-	Health     int
-	WeightLoss float64
+	Name       string  `json:"name"`
+	Happiness  int     `json:"happiness"`
+	Energy     int     `json:"energy"`
+	Health     int     `json:"health"`
+	WeightLoss float64 `json:"weight_loss"`
+	Toy        string  `json:"toy,omitempty"`   // shop toy used up by the game, if any
+	Skill      string  `json:"skill,omitempty"` // skill trained by the game, if any
+	SkillGain  float64 `json:"skill_gain,omitempty"`
 }
 
 var availableGames = []Game{
-	{Name: "🎾 Play Ball", Happiness: 20, Energy: -15, Health: 5, WeightLoss: 0.5},
-	{Name: "🏃‍♂️ Run Around", Happiness: 15, Energy: -25, Health: 10, WeightLoss: 1.0},
-	{Name: "🎵 Sing Songs", Happiness: 25, Energy: -5, Health: 3, WeightLoss: 0.1},
-	{Name: "🎨 Draw Pictures", Happiness: 30, Energy: -10, Health: 2, WeightLoss: 0.2},
-	{Name: "🧩 Solve Puzzle", Happiness: 35, Energy: -20, Health: 8, WeightLoss: 0.3},
-	{Name: "🎭 Dance Party", Happiness: 40, Energy: -30, Health: 12, WeightLoss: 1.5},
-	{Name: "📚 Read Books", Happiness: 15, Energy: -5, Health: 5, WeightLoss: 0.1},
-	{Name: "🎪 Play Hide & Seek", Happiness: 25, Energy: -20, Health: 7, WeightLoss: 0.8},
+	{Name: "🎾 Play Ball", Happiness: 20, Energy: -15, Health: 5, WeightLoss: 0.5, Toy: "🎾 Tennis Ball", Skill: skillStrength, SkillGain: 2},
+	{Name: "🏃‍♂️ Run Around", Happiness: 15, Energy: -25, Health: 10, WeightLoss: 1.0, Skill: skillStrength, SkillGain: 4},
+	{Name: "🎵 Sing Songs", Happiness: 25, Energy: -5, Health: 3, WeightLoss: 0.1, Skill: skillCreativity, SkillGain: 3},
+	{Name: "🎨 Draw Pictures", Happiness: 30, Energy: -10, Health: 2, WeightLoss: 0.2, Toy: "🖍️ Crayons", Skill: skillCreativity, SkillGain: 5},
+	{Name: "🧩 Solve Puzzle", Happiness: 35, Energy: -20, Health: 8, WeightLoss: 0.3, Toy: "🧩 Jigsaw Puzzle", Skill: skillIntelligence, SkillGain: 5},
+	{Name: "🎭 Dance Party", Happiness: 40, Energy: -30, Health: 12, WeightLoss: 1.5, Skill: skillCreativity, SkillGain: 2},
+	{Name: "📚 Read Books", Happiness: 15, Energy: -5, Health: 5, WeightLoss: 0.1, Skill: skillIntelligence, SkillGain: 4},
+	{Name: "🎪 Play Hide & Seek", Happiness: 25, Energy: -20, Health: 7, WeightLoss: 0.8, Skill: skillIntelligence, SkillGain: 1},
 }
 
 func (a *App) generatePlayList(listPlay *tview.List) {
@@ -60,9 +62,17 @@ func (a *App) generatePlayList(listPlay *tview.List) {
 			energyChange = fmt.Sprintf("Energy: +%d", game.Energy)
 		}
 
+		toy := ""
+		if game.Toy != "" {
+			toy = fmt.Sprintf(" [needs %s, x%d]", game.Toy, t.Inventory[game.Toy])
+		}
+		if game.Skill != "" {
+			toy += fmt.Sprintf(" [%s +%.0f]", game.Skill, game.SkillGain)
+		}
+
 		listPlay.AddItem(
-			fmt.Sprintf("%s (Happiness: +%d, %s, Health: +%d, Weight: -%.1fg)",
-				game.Name, game.Happiness, energyChange, game.Health, game.WeightLoss),
+			fmt.Sprintf("%s%s (Happiness: +%d, %s, Health: +%d, Weight: -%.1fg)",
+				game.Name, toy, game.Happiness, energyChange, game.Health, game.WeightLoss),
 			"",
 			0,
 			func() { a.playWithTamagotchi(gameIndex) },
@@ -75,40 +85,79 @@ func (a *App) generatePlayList(listPlay *tview.List) {
 	listPlay.AddItem(fmt.Sprintf("Current Energy: %d/100", t.Energy), "", 0, nil)
 	listPlay.AddItem(fmt.Sprintf("Current Weight: %.1f grams", t.Weight), "", 0, nil)
 	listPlay.AddItem(fmt.Sprintf("Last Play: %s", t.LastPlay.Format("15:04")), "", 0, nil)
+	listPlay.AddItem(fmt.Sprintf("Coins: %d (earned from game scores)", t.Coins), "", 0, nil)
 }
 
 func (a *App) playWithTamagotchi(gameIndex int) {
 	if gameIndex < 0 || gameIndex >= len(availableGames) {
 		return
 	}
+	a.act(methodPlay, actionParams{Game: availableGames[gameIndex].Name})
+}
+
+// playPet plays a game with the named pet, or the current one.
+func (a *App) playPet(pet, name string) error {
+	game, ok := findGame(name)
+	if !ok {
+		return fmt.Errorf("unknown game %q", name)
+	}
 
-	game := availableGames[gameIndex]
-	now := time.Now()
+	now := a.now()
 
 	a.stateMu.Lock()
-	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+	t, err := a.livingPetLocked(pet)
+	if err != nil {
 		a.stateMu.Unlock()
-		return
+		return err
 	}
 
-	if a.currentTamagotchi.Energy < 10 {
+	if t.Energy < 10 {
 		a.stateMu.Unlock()
-		return
+		return fmt.Errorf("%s is too tired to play", t.Name)
 	}
 
-	a.currentTamagotchi.Happiness = min(100, a.currentTamagotchi.Happiness+game.Happiness)
-	a.currentTamagotchi.Energy = max(0, min(100, a.currentTamagotchi.Energy+game.Energy))
-	a.currentTamagotchi.Health = min(100, a.currentTamagotchi.Health+game.Health)
-	if a.currentTamagotchi.Weight-game.WeightLoss < 10.0 {
-		a.currentTamagotchi.Weight = 10.0
+	if game.Toy != "" && !t.takeItem(game.Toy) {
+		a.stateMu.Unlock()
+		return fmt.Errorf("%s needs a %s", game.Name, game.Toy)
+	}
+
+	t.trainSkill(game.Skill, game.SkillGain, now)
+
+	score := gameScore(game, t.Energy)
+	coins := score / 10
+	t.Coins += coins
+	t.Happiness = min(100, t.Happiness+game.Happiness)
+	t.Energy = max(0, min(100, t.Energy+game.Energy))
+	t.Health = min(100, t.Health+game.Health)
+	if t.Weight-game.WeightLoss < 10.0 {
+		t.Weight = 10.0
 	} else {
-		a.currentTamagotchi.Weight -= game.WeightLoss
+		t.Weight -= game.WeightLoss
 	}
-	a.currentTamagotchi.LastPlay = now
+	t.LastPlay = now
+	pet = t.Name
+	quests := a.questActionLocked(t, questPlay, game.Name, now)
 	a.stateMu.Unlock()
 
 	a.updateConfigFromState()
-	a.addGameEvent("PLAY", fmt.Sprintf("Played %s! Happiness +%d, Energy %d", game.Name, game.Happiness, game.Energy))
+	a.addPetEvent(pet, "PLAY", fmt.Sprintf("Played %s! Happiness +%d, Energy %d, Score %d (+%d coins)", game.Name, game.Happiness, game.Energy, score, coins))
+	a.announceQuests(pet, quests, now)
+	return nil
+}
+
+func findGame(name string) (Game, bool) {
+	for _, game := range availableGames {
+		if game.Name == name {
+			return game, true
+		}
+	}
+	return Game{}, false
+}
+
+// gameScore rates how well a game went. A well-rested pet plays better, so
+// the score scales the game's happiness value by the energy it started with.
+func gameScore(game Game, energy int) int {
+	return game.Happiness * (50 + energy) / 50
 }
 
 func (a *App) playPage() (title string, content tview.Primitive) {
@@ -0,0 +1,326 @@
+package app
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+const (
+	questFeed  = "feed"
+	questPlay  = "play"
+	questSleep = "sleep"
+
+	dailyQuests  = 3
+	weeklyQuests = 1
+)
+
+// questReward is paid out when a quest is completed.
+type questReward struct {
+	Coins     int
+	Happiness int
+	Item      string
+}
+
+// questHold asks for a stat to stay in range between two hours of the day.
+type questHold struct {
+	Stat      func(t *Tamagotchi) int
+	OK        func(v int) bool
+	From      int // hour of the day
+	Until     int
+	UntilText string
+}
+
+// questTemplate describes a kind of quest. Quests count actions, optionally
+// only distinct ones, or hold a stat in range for part of the day.
+type questTemplate struct {
+	ID       string
+	Text     string // may contain %d for the goal
+	Weekly   bool
+	Action   string
+	Distinct bool
+	Goal     int
+	Hold     *questHold
+	Reward   questReward
+}
+
+var questTemplates = []questTemplate{
+	{ID: "feed", Text: "Feed your pet %d times", Action: questFeed, Goal: 3, Reward: questReward{Coins: 15}},
+	{ID: "play_variety", Text: "Play %d different games", Action: questPlay, Distinct: true, Goal: 3, Reward: questReward{Coins: 10, Happiness: 10}},
+	{ID: "nap", Text: "Put your pet to sleep", Action: questSleep, Goal: 1, Reward: questReward{Item: "🍎 Apple"}},
+	{
+		ID:   "full_morning",
+		Text: "Keep hunger below 40 until noon",
+		Goal: 1,
+		Hold: &questHold{
+			Stat:      func(t *Tamagotchi) int { return t.Hunger },
+			OK:        func(v int) bool { return v < 40 },
+			From:      0,
+			Until:     12,
+			UntilText: "noon",
+		},
+		Reward: questReward{Coins: 20, Item: "🍕 Pizza"},
+	},
+	{
+		ID:   "cheerful_afternoon",
+		Text: "Keep happiness at 60 or more from noon to 6pm",
+		Goal: 1,
+		Hold: &questHold{
+			Stat:      func(t *Tamagotchi) int { return t.Happiness },
+			OK:        func(v int) bool { return v >= 60 },
+			From:      12,
+			Until:     18,
+			UntilText: "6pm",
+		},
+		Reward: questReward{Coins: 15, Happiness: 5},
+	},
+	{ID: "weekly_feed", Text: "Feed your pet %d times this week", Weekly: true, Action: questFeed, Goal: 15, Reward: questReward{Coins: 50}},
+	{ID: "weekly_play_variety", Text: "Play %d different games this week", Weekly: true, Action: questPlay, Distinct: true, Goal: 6, Reward: questReward{Coins: 30, Item: "🧩 Jigsaw Puzzle"}},
+	{ID: "weekly_sleep", Text: "Sleep %d times this week", Weekly: true, Action: questSleep, Goal: 7, Reward: questReward{Happiness: 20, Item: "🧸 Teddy Bear"}},
+}
+
+// Quest is a pet's progress on one quest of the current day or week.
+type Quest struct {
+	ID       string
+	Period   string // day ("2006-01-02") or ISO week ("2006-W01") it belongs to
+	Progress int
+	Seen     []string // actions already counted by distinct quests
+	Failed   bool
+	Done     time.Time // zero until completed
+}
+
+func findQuestTemplate(id string) (questTemplate, bool) {
+	for _, tmpl := range questTemplates {
+		if tmpl.ID == id {
+			return tmpl, true
+		}
+	}
+	return questTemplate{}, false
+}
+
+func (tmpl questTemplate) describe() string {
+	if strings.Contains(tmpl.Text, "%d") {
+		return fmt.Sprintf(tmpl.Text, tmpl.Goal)
+	}
+	return tmpl.Text
+}
+
+func (r questReward) describe() string {
+	var parts []string
+	if r.Coins > 0 {
+		parts = append(parts, fmt.Sprintf("+%d coins", r.Coins))
+	}
+	if r.Happiness > 0 {
+		parts = append(parts, fmt.Sprintf("+%d happiness", r.Happiness))
+	}
+	if r.Item != "" {
+		parts = append(parts, r.Item)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dailyPeriod(at time.Time) string {
+	return at.Format("2006-01-02")
+}
+
+func weeklyPeriod(at time.Time) string {
+	year, week := at.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// generateQuests picks the quests of one period. The choice depends only on
+// the pet and the period, so it is the same however often it is made.
+func generateQuests(petID, period string, weekly bool, count int) []Quest {
+	h := fnv.New64a()
+	h.Write([]byte(petID + "/" + period))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	var candidates []questTemplate
+	for _, tmpl := range questTemplates {
+		if tmpl.Weekly == weekly {
+			candidates = append(candidates, tmpl)
+		}
+	}
+
+	var quests []Quest
+	for _, i := range rng.Perm(len(candidates)) {
+		if len(quests) == count {
+			break
+		}
+		quests = append(quests, Quest{ID: candidates[i].ID, Period: period})
+	}
+	return quests
+}
+
+// activeQuests returns the pet's quests for the day and week containing at,
+// keeping progress already made and generating the rest.
+func (t *Tamagotchi) activeQuests(at time.Time) []Quest {
+	day, week := dailyPeriod(at), weeklyPeriod(at)
+
+	var quests []Quest
+	have := make(map[string]bool)
+	for _, q := range t.Quests {
+		if q.Period == day || q.Period == week {
+			quests = append(quests, q)
+			have[q.Period] = true
+		}
+	}
+	if !have[day] {
+		quests = append(quests, generateQuests(t.ID, day, false, dailyQuests)...)
+	}
+	if !have[week] {
+		quests = append(quests, generateQuests(t.ID, week, true, weeklyQuests)...)
+	}
+	return quests
+}
+
+// questActionLocked counts an action towards the pet's quests and pays out
+// the ones it completes, which it returns for announcing.
+func (a *App) questActionLocked(t *Tamagotchi, action, name string, at time.Time) []Quest {
+	t.Quests = t.activeQuests(at)
+
+	var done []Quest
+	for i := range t.Quests {
+		q := &t.Quests[i]
+		tmpl, ok := findQuestTemplate(q.ID)
+		if !ok || tmpl.Action != action || !q.Done.IsZero() {
+			continue
+		}
+		if tmpl.Distinct {
+			if slices.Contains(q.Seen, name) {
+				continue
+			}
+			q.Seen = append(q.Seen, name)
+		}
+		q.Progress++
+		if q.Progress >= tmpl.Goal {
+			q.Done = at
+			t.payQuestReward(tmpl.Reward)
+			done = append(done, *q)
+		}
+	}
+	return done
+}
+
+// questTickLocked checks the pet's hold quests after a tick.
+func (a *App) questTickLocked(t *Tamagotchi, at time.Time) {
+	t.Quests = t.activeQuests(at)
+
+	for i := range t.Quests {
+		q := &t.Quests[i]
+		tmpl, ok := findQuestTemplate(q.ID)
+		if !ok || tmpl.Hold == nil || !q.Done.IsZero() || q.Failed || q.Period != dailyPeriod(at) {
+			continue
+		}
+
+		hour := at.Hour()
+		switch {
+		case hour < tmpl.Hold.From:
+			// Not started yet
+		case hour < tmpl.Hold.Until:
+			if !tmpl.Hold.OK(tmpl.Hold.Stat(t)) {
+				q.Failed = true
+			} else if q.Progress == 0 && hour == tmpl.Hold.From {
+				q.Progress = 1 // watched from the start of the window
+			}
+		case q.Progress > 0:
+			q.Done = at
+			t.payQuestReward(tmpl.Reward)
+			a.announceQuests(t.Name, []Quest{*q}, at)
+		default:
+			// The start of the window was missed, so it can't be vouched for.
+			q.Failed = true
+		}
+	}
+}
+
+func (t *Tamagotchi) payQuestReward(r questReward) {
+	t.Coins += r.Coins
+	t.Happiness = min(100, t.Happiness+r.Happiness)
+	if r.Item != "" {
+		t.addItem(r.Item, 1)
+	}
+}
+
+func (a *App) announceQuests(pet string, done []Quest, at time.Time) {
+	for _, q := range done {
+		tmpl, ok := findQuestTemplate(q.ID)
+		if !ok {
+			continue
+		}
+		a.addPetEventAt(pet, "QUEST", fmt.Sprintf("Quest complete: %s! Reward: %s", tmpl.describe(), tmpl.Reward.describe()), at)
+	}
+}
+
+// questLine renders a quest for the Status page.
+func questLine(q Quest) string {
+	tmpl, ok := findQuestTemplate(q.ID)
+	if !ok {
+		return q.ID
+	}
+
+	kind := "Daily"
+	if tmpl.Weekly {
+		kind = "Weekly"
+	}
+
+	var state string
+	switch {
+	case !q.Done.IsZero():
+		state = "✅"
+	case q.Failed:
+		state = "❌"
+	case tmpl.Hold != nil && q.Progress > 0:
+		state = fmt.Sprintf("⏳ until %s", tmpl.Hold.UntilText)
+	case tmpl.Hold != nil:
+		state = "⬜"
+	default:
+		state = fmt.Sprintf("⬜ %d/%d", q.Progress, tmpl.Goal)
+	}
+
+	return fmt.Sprintf("%s %s: %s (%s)", state, kind, tmpl.describe(), tmpl.Reward.describe())
+}
+
+func questsFromConfig(quests []config.QuestConfig) []Quest {
+	out := make([]Quest, 0, len(quests))
+	for _, q := range quests {
+		out = append(out, Quest{
+			ID:       q.ID,
+			Period:   q.Period,
+			Progress: q.Progress,
+			Seen:     append([]string(nil), q.Seen...),
+			Failed:   q.Failed,
+			Done:     q.Done,
+		})
+	}
+	return out
+}
+
+func questsToConfig(quests []Quest) []config.QuestConfig {
+	out := make([]config.QuestConfig, 0, len(quests))
+	for _, q := range quests {
+		out = append(out, config.QuestConfig{
+			ID:       q.ID,
+			Period:   q.Period,
+			Progress: q.Progress,
+			Seen:     append([]string(nil), q.Seen...),
+			Failed:   q.Failed,
+			Done:     q.Done,
+		})
+	}
+	return out
+}
+
+func copyQuests(quests []Quest) []Quest {
+	out := make([]Quest, 0, len(quests))
+	for _, q := range quests {
+		q.Seen = append([]string(nil), q.Seen...)
+		out = append(out, q)
+	}
+	return out
+}
@@ -0,0 +1,301 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+const (
+	// callTimeout is how long the daemon has to answer a call.
+	callTimeout = 10 * time.Second
+
+	// followInterval is how often a client game refreshes the daemon's pets.
+	followInterval = time.Second
+)
+
+// Client talks to a running daemon over its control socket.
+type Client struct {
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+	mu   sync.Mutex
+	id   int
+}
+
+// DialDaemon connects to the daemon, failing when none is running.
+func DialDaemon() (*Client, error) {
+	path, err := config.SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn)}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Call calls a method of the daemon and decodes its result into result,
+// unless that is nil.
+func (c *Client) Call(method string, params, result any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.id++
+	req := rpcMessage{JSONRPC: "2.0", ID: json.RawMessage(strconv.Itoa(c.id)), Method: method}
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = raw
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(callTimeout)); err != nil {
+		return err
+	}
+	if err := c.enc.Encode(req); err != nil {
+		return err
+	}
+	var resp rpcMessage
+	if err := c.dec.Decode(&resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// Subscribe calls fn with the events of the named pet, or of every pet, as
+// the daemon logs them, until the connection is lost. The client can't make
+// other calls afterwards.
+func (c *Client) Subscribe(pet string, fn func(GameEvent)) error {
+	if err := c.Call(methodSubscribe, petParams{Pet: pet}, nil); err != nil {
+		return err
+	}
+	if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	for {
+		var msg rpcMessage
+		if err := c.dec.Decode(&msg); err != nil {
+			return err
+		}
+		if msg.Method != methodEvent {
+			continue
+		}
+		var event GameEvent
+		if err := json.Unmarshal(msg.Params, &event); err != nil {
+			return err
+		}
+		fn(event)
+	}
+}
+
+// Statuses reports on the named pet, or on every pet.
+func (c *Client) Statuses(pet string) ([]PetStatus, error) {
+	var statuses []PetStatus
+	err := c.Call(methodStatus, petParams{Pet: pet}, &statuses)
+	return statuses, err
+}
+
+// StartVacation sends the pets on vacation and describes the vacation.
+func (c *Client) StartVacation(mode string, days int) (string, error) {
+	var status string
+	err := c.Call(methodVacation, vacationParams{Action: vacationStart, Mode: mode, Days: days}, &status)
+	return status, err
+}
+
+// EndVacation cuts the vacation short and describes what's left.
+func (c *Client) EndVacation() (string, error) {
+	var status string
+	err := c.Call(methodVacation, vacationParams{Action: vacationEnd}, &status)
+	return status, err
+}
+
+// SetSitter changes the pet sitter's settings and describes them.
+func (c *Client) SetSitter(s config.SitterConfig) (string, error) {
+	var description string
+	err := c.Call(methodSitter, s, &description)
+	return description, err
+}
+
+// SetNotify changes the notifier's settings and describes them.
+func (c *Client) SetNotify(s config.NotifyConfig) (string, error) {
+	var description string
+	err := c.Call(methodNotify, s, &description)
+	return description, err
+}
+
+// SetWebhooks replaces the webhooks and describes them.
+func (c *Client) SetWebhooks(hooks []config.WebhookConfig) ([]string, error) {
+	var descriptions []string
+	err := c.Call(methodWebhooks, hooks, &descriptions)
+	return descriptions, err
+}
+
+// Save has the daemon write the save, so it can be read.
+func (c *Client) Save() error {
+	return c.Call(methodSave, nil, nil)
+}
+
+// NewClientApp returns the game as a client of the running daemon: it shows
+// the daemon's pets and sends it the player's care instead of ticking on its
+// own. Only the current pet shown is kept from cfg.
+func NewClientApp(cfg *config.Config, c *Client) (*App, error) {
+	a := newApp(cfg, time.Now)
+	a.remote = c
+
+	var s gameState
+	if err := c.Call(methodState, nil, &s); err != nil {
+		return nil, err
+	}
+	if cfg.App.ActivePet != "" {
+		s.Active = cfg.App.ActivePet
+	}
+
+	a.replayJournal()
+	a.loadHistory()
+	a.applyState(s, s.Active)
+	a.buildLayout()
+
+	go a.followDaemon()
+
+	return a, nil
+}
+
+// callDaemon calls a method of the daemon for the player and shows the
+// outcome straight away.
+func (a *App) callDaemon(method string, params any) error {
+	if err := a.remote.Call(method, params, nil); err != nil {
+		return err
+	}
+	return a.syncWithDaemon()
+}
+
+// act takes care of the current pet, through the daemon when the game is its
+// client, logging why when the care can't be given.
+func (a *App) act(method string, p actionParams) {
+	a.stateMu.RLock()
+	if a.currentTamagotchi != nil {
+		p.Pet = a.currentTamagotchi.Name
+	}
+	a.stateMu.RUnlock()
+
+	var err error
+	if a.remote != nil {
+		err = a.callDaemon(method, p)
+	} else {
+		err = a.runAction(method, p)
+	}
+	if err != nil {
+		a.addGameEvent(strings.ToUpper(method), fmt.Sprintf("Can't %s %s: %v", method, p.Pet, err))
+	}
+}
+
+// refuseAsClient logs that something can't be done while the game is a
+// client of the daemon, which only takes care and vacations.
+func (a *App) refuseAsClient(eventType string) bool {
+	if a.remote == nil {
+		return false
+	}
+	a.addGameEvent(eventType, "Only feeding, playing, sleeping and vacations work while the daemon is running. Stop it to do this.")
+	return true
+}
+
+func (a *App) syncWithDaemon() error {
+	var s gameState
+	if err := a.remote.Call(methodState, nil, &s); err != nil {
+		return err
+	}
+	a.stateMu.RLock()
+	current := s.Active
+	if a.currentTamagotchi != nil {
+		current = a.currentTamagotchi.Name
+	}
+	a.stateMu.RUnlock()
+
+	a.applyState(s, current)
+	return nil
+}
+
+// applyState mirrors the daemon's game, showing the named pet if it is
+// still there.
+func (a *App) applyState(s gameState, current string) {
+	pets := make([]*Tamagotchi, 0, len(s.Pets))
+	for _, cfg := range s.Pets {
+		pets = append(pets, tamagotchiFromConfig(cfg))
+	}
+	achievements := achievementsFromConfig(s.Achievements)
+
+	a.stateMu.Lock()
+	a.pets = pets
+	a.currentTamagotchi = a.findPetLocked(current)
+	if a.currentTamagotchi == nil && len(pets) > 0 {
+		a.currentTamagotchi = pets[0]
+	}
+	a.lineage = lineageFromConfig(s.Lineage)
+	a.nextWorldEvent = s.NextWorldEvent
+	a.vacation = s.Vacation
+	a.sitter = s.Sitter
+	a.notify = s.Notify
+	a.webhooks = s.Webhooks
+	a.stateMu.Unlock()
+
+	a.achievementsMu.Lock()
+	achievements.announce = a.achievements.announce
+	a.achievements = achievements
+	a.achievementsMu.Unlock()
+
+	a.requestRefresh()
+}
+
+// followDaemon keeps a client game in step with the daemon: events as they
+// are logged, the pets every followInterval, and the memorial and charts as
+// often as the daemon saves them.
+func (a *App) followDaemon() {
+	if events, err := DialDaemon(); err != nil {
+		log.Printf("failed to follow daemon events: %v", err)
+	} else {
+		go func() {
+			defer events.Close()
+			err := events.Subscribe("", func(event GameEvent) {
+				a.eventsMu.Lock()
+				a.pushEventLocked(event)
+				a.eventsMu.Unlock()
+				a.requestRefresh()
+			})
+			log.Printf("stopped following daemon events: %v", err)
+		}()
+	}
+
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	lastLoad := time.Now()
+	for range ticker.C {
+		if err := a.syncWithDaemon(); err != nil {
+			a.addGameEvent("DAEMON", fmt.Sprintf("Lost the daemon: %v. Restart termagotchi to carry on. 🔌", err))
+			return
+		}
+		if time.Since(lastLoad) >= daemonSaveInterval {
+			a.loadHistory()
+			lastLoad = time.Now()
+		}
+	}
+}
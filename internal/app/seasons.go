@@ -0,0 +1,216 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	seasonSpring = "spring"
+	seasonSummer = "summer"
+	seasonAutumn = "autumn"
+	seasonWinter = "winter"
+
+	holidayNewYear   = "new_year"
+	holidayHalloween = "halloween"
+	holidayBirthday  = "birthday"
+)
+
+var seasonIcons = map[string]string{
+	seasonSpring: "🌸",
+	seasonSummer: "☀️",
+	seasonAutumn: "🍂",
+	seasonWinter: "❄️",
+}
+
+// seasonalFoods are only sold, and only shown on the Feed page, in their
+// season. Leftovers can still be eaten afterwards.
+var seasonalFoods = map[string][]Food{
+	seasonSpring: {{Name: "🍓 Strawberries", Nutrition: 15, Happiness: 20, Energy: 10, WeightGain: 0.4, Price: 5}},
+	seasonSummer: {{Name: "🍉 Watermelon", Nutrition: 20, Happiness: 15, Energy: 20, WeightGain: 0.5, Price: 5}},
+	seasonAutumn: {{Name: "🥧 Pumpkin Pie", Nutrition: 35, Happiness: 25, Energy: 15, WeightGain: 2.0, Price: 9}},
+	seasonWinter: {{Name: "☕ Hot Cocoa", Nutrition: 10, Happiness: 25, Energy: 25, WeightGain: 0.8, Price: 6}},
+}
+
+// seasonOrder is the order the seasonal foods are listed in.
+var seasonOrder = []string{seasonSpring, seasonSummer, seasonAutumn, seasonWinter}
+
+// seasonDecorations are drawn around the sprite, above and below it.
+var seasonDecorations = map[string]struct{ Top, Bottom []string }{
+	seasonSpring: {Bottom: []string{",*,    ,*,  ,*,"}},
+	seasonSummer: {Top: []string{"          \\|/", "          -O-", "          /|\\"}},
+	seasonAutumn: {Top: []string{" ~     ~      ~", "    ~      ~   "}},
+	seasonWinter: {Top: []string{"*   .   *   .  *", "  .   *   .   * "}, Bottom: []string{"________________"}},
+}
+
+// holidayDecorations replace the season's top decoration on a holiday.
+var holidayDecorations = map[string][]string{
+	holidayNewYear:   {"* . ' * . ' * .", "' * . ' * . ' *"},
+	holidayHalloween: {" .-\"\"-.    .-\"\"-.", " |^  ^|    |^  ^|", " '-vv-'    '-vv-'"},
+	holidayBirthday:  {"   i i i i i   ", "  |~~~~~~~~~|  ", "  |_________|  "},
+}
+
+// holiday is a special day a pet celebrates once a year.
+type holiday struct {
+	ID      string
+	Name    string
+	Message string // with %s for the pet's name
+}
+
+// season returns the northern hemisphere season of the given date.
+func season(at time.Time) string {
+	switch at.Month() {
+	case time.March, time.April, time.May:
+		return seasonSpring
+	case time.June, time.July, time.August:
+		return seasonSummer
+	case time.September, time.October, time.November:
+		return seasonAutumn
+	default:
+		return seasonWinter
+	}
+}
+
+// holidaysOn returns the holidays the pet celebrates on the day of at.
+func (t *Tamagotchi) holidaysOn(at time.Time) []holiday {
+	var holidays []holiday
+	_, month, day := at.Date()
+	if month == time.January && day == 1 {
+		holidays = append(holidays, holiday{
+			ID:      holidayNewYear,
+			Name:    "New Year",
+			Message: fmt.Sprintf("Happy New Year %d, %%s! 🎆", at.Year()),
+		})
+	}
+	if month == time.October && day == 31 {
+		holidays = append(holidays, holiday{
+			ID:      holidayHalloween,
+			Name:    "Halloween",
+			Message: "Trick or treat! %s got a 🍫 Chocolate 🎃",
+		})
+	}
+	if years := t.birthdayOn(at); years > 0 {
+		holidays = append(holidays, holiday{
+			ID:      holidayBirthday,
+			Name:    fmt.Sprintf("%s birthday", ordinal(years)),
+			Message: fmt.Sprintf("Happy %s birthday, %%s! 🎂 Gift: +%d coins", ordinal(years), birthdayCoins*years),
+		})
+	}
+	return holidays
+}
+
+// birthdayCoins is the birthday gift per year of age.
+const birthdayCoins = 25
+
+// birthdayOn returns how many years old the pet turns on the day of at, or 0
+// when it isn't its birthday. Pets born on 29 February celebrate on the 28th
+// in other years.
+func (t *Tamagotchi) birthdayOn(at time.Time) int {
+	born := t.Created.In(at.Location())
+	years := at.Year() - born.Year()
+	if years <= 0 {
+		return 0
+	}
+
+	month, day := born.Month(), born.Day()
+	if month == time.February && day == 29 && !isLeapYear(at.Year()) {
+		day = 28
+	}
+	if at.Month() != month || at.Day() != day {
+		return 0
+	}
+	return years
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// holidayTickLocked celebrates the holidays of the day of at that the pet
+// hasn't celebrated yet this year.
+func (a *App) holidayTickLocked(t *Tamagotchi, at time.Time) {
+	for _, h := range t.holidaysOn(at) {
+		if t.Celebrated[h.ID] == at.Year() {
+			continue
+		}
+		if t.Celebrated == nil {
+			t.Celebrated = make(map[string]int)
+		}
+		t.Celebrated[h.ID] = at.Year()
+
+		t.Happiness = min(100, t.Happiness+20)
+		switch h.ID {
+		case holidayHalloween:
+			t.addItem("🍫 Chocolate", 1)
+		case holidayBirthday:
+			t.Coins += birthdayCoins * t.birthdayOn(at)
+		}
+		a.addPetEventAt(t.Name, "HOLIDAY", fmt.Sprintf(h.Message, t.Name), at)
+	}
+}
+
+// foodCatalog lists the foods on offer at the given time: the year-round
+// ones followed by those of the season.
+func foodCatalog(at time.Time) []Food {
+	foods := append([]Food(nil), availableFoods...)
+	return append(foods, seasonalFoods[season(at)]...)
+}
+
+// allFoods lists every food, in season or not.
+func allFoods() []Food {
+	foods := append([]Food(nil), availableFoods...)
+	for _, s := range seasonOrder {
+		foods = append(foods, seasonalFoods[s]...)
+	}
+	return foods
+}
+
+func findFood(name string) (Food, bool) {
+	for _, food := range allFoods() {
+		if food.Name == name {
+			return food, true
+		}
+	}
+	return Food{}, false
+}
+
+// decorateSprite draws the season's decorations, or the day's holiday ones,
+// around the sprite.
+func (t *Tamagotchi) decorateSprite(sprite string, at time.Time) string {
+	decoration := seasonDecorations[season(at)]
+	top := decoration.Top
+	for _, h := range t.holidaysOn(at) {
+		top = holidayDecorations[h.ID]
+	}
+
+	grid := spriteGrid(sprite)
+	grid = overlaySprite(grid, decoration.Bottom, len(grid), 0)
+	grid = overlaySprite(grid, top, -len(top), 0)
+	return joinSpriteGrid(grid)
+}
+
+func copyCelebrated(celebrated map[string]int) map[string]int {
+	if celebrated == nil {
+		return nil
+	}
+	out := make(map[string]int, len(celebrated))
+	for id, year := range celebrated {
+		out[id] = year
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestBirthdayOn(t *testing.T) {
+	tests := []struct {
+		name string
+		born time.Time
+		at   time.Time
+		want int
+	}{
+		{"day of birth", date(2025, time.June, 10, 9), date(2025, time.June, 10, 20), 0},
+		{"first birthday", date(2025, time.June, 10, 9), date(2026, time.June, 10, 0), 1},
+		{"first birthday, late evening", date(2025, time.June, 10, 9), date(2026, time.June, 10, 23), 1},
+		{"day before", date(2025, time.June, 10, 9), date(2026, time.June, 9, 23), 0},
+		{"day after", date(2025, time.June, 10, 9), date(2026, time.June, 11, 0), 0},
+		{"third birthday", date(2025, time.June, 10, 9), date(2028, time.June, 10, 12), 3},
+		{"Feb 29, non-leap year", date(2024, time.February, 29, 12), date(2025, time.February, 28, 12), 1},
+		{"Feb 29, not on Mar 1 of a non-leap year", date(2024, time.February, 29, 12), date(2025, time.March, 1, 12), 0},
+		{"Feb 29, leap year", date(2024, time.February, 29, 12), date(2028, time.February, 29, 12), 4},
+		{"Feb 29, not on Feb 28 of a leap year", date(2024, time.February, 29, 12), date(2028, time.February, 28, 12), 0},
+		{"New Year's Day pet", date(2025, time.January, 1, 0), date(2026, time.January, 1, 8), 1},
+		{"New Year's Eve pet on New Year", date(2025, time.December, 31, 23), date(2026, time.January, 1, 0), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pet := &Tamagotchi{Created: tt.born}
+			if got := pet.birthdayOn(tt.at); got != tt.want {
+				t.Errorf("birthdayOn(%s) = %d, want %d", tt.at.Format(time.DateTime), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHolidaysOn(t *testing.T) {
+	born := date(2025, time.January, 1, 10)
+	tests := []struct {
+		name string
+		born time.Time
+		at   time.Time
+		want []string
+	}{
+		{"ordinary day", born, date(2025, time.July, 14, 12), nil},
+		{"New Year, midnight", date(2024, time.March, 3, 0), date(2026, time.January, 1, 0), []string{holidayNewYear}},
+		{"not New Year's Eve", date(2024, time.March, 3, 0), date(2025, time.December, 31, 23), nil},
+		{"Halloween", born, date(2025, time.October, 31, 18), []string{holidayHalloween}},
+		{"born on New Year's Day", born, date(2025, time.January, 1, 12), []string{holidayNewYear}},
+		{"first birthday on New Year", born, date(2026, time.January, 1, 12), []string{holidayNewYear, holidayBirthday}},
+		{"Feb 29 birthday in a non-leap year", date(2024, time.February, 29, 0), date(2027, time.February, 28, 0), []string{holidayBirthday}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pet := &Tamagotchi{Created: tt.born}
+			var got []string
+			for _, h := range pet.holidaysOn(tt.at) {
+				got = append(got, h.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("holidaysOn(%s) = %v, want %v", tt.at.Format(time.DateTime), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoodCatalogSeasons(t *testing.T) {
+	tests := []struct {
+		at   time.Time
+		want string // the seasonal food on offer
+	}{
+		{date(2026, time.February, 28, 23), "☕ Hot Cocoa"},
+		{date(2028, time.February, 29, 23), "☕ Hot Cocoa"},
+		{date(2026, time.March, 1, 0), "🍓 Strawberries"},
+		{date(2026, time.May, 31, 23), "🍓 Strawberries"},
+		{date(2026, time.June, 1, 0), "🍉 Watermelon"},
+		{date(2026, time.August, 31, 23), "🍉 Watermelon"},
+		{date(2026, time.September, 1, 0), "🥧 Pumpkin Pie"},
+		{date(2026, time.November, 30, 23), "🥧 Pumpkin Pie"},
+		{date(2026, time.December, 1, 0), "☕ Hot Cocoa"},
+		{date(2027, time.January, 1, 0), "☕ Hot Cocoa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.at.Format(time.DateTime), func(t *testing.T) {
+			foods := foodCatalog(tt.at)
+			if len(foods) != len(availableFoods)+1 {
+				t.Fatalf("%d foods on offer, want the %d year-round ones and one seasonal", len(foods), len(availableFoods))
+			}
+			for i, food := range availableFoods {
+				if foods[i].Name != food.Name {
+					t.Errorf("food %d is %s, want %s", i, foods[i].Name, food.Name)
+				}
+			}
+			if got := foods[len(foods)-1].Name; got != tt.want {
+				t.Errorf("seasonal food is %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
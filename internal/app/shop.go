@@ -0,0 +1,251 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	itemKindFood      = "food"
+	itemKindToy       = "toy"
+	itemKindMedicine  = "medicine"
+	itemKindAccessory = "accessory"
+
+	starterCoins = 50
+)
+
+type Item struct {
+	Name    string
+	Kind    string
+	Price   int
+	Health  int      // restored when a medicine is used
+	Durable bool     // kept forever instead of being used up
+	Comfort int      // passive happiness per tick while a durable toy is owned
+	Slot    string   // where an accessory is worn
+	Art     []string // accessory overlay drawn onto the sprite
+}
+
+var availableItems = []Item{
+	{Name: "🎾 Tennis Ball", Kind: itemKindToy, Price: 4},
+	{Name: "🖍️ Crayons", Kind: itemKindToy, Price: 5},
+	{Name: "🧩 Jigsaw Puzzle", Kind: itemKindToy, Price: 8},
+	{Name: "🧸 Teddy Bear", Kind: itemKindToy, Price: 60, Durable: true, Comfort: 1},
+	{Name: "🎸 Toy Guitar", Kind: itemKindToy, Price: 90, Durable: true, Comfort: 2},
+	{Name: "🎩 Top Hat", Kind: itemKindAccessory, Price: 45, Durable: true, Slot: slotHead, Art: []string{" ___ ", "_|_|_"}},
+	{Name: "🎀 Bow", Kind: itemKindAccessory, Price: 30, Durable: true, Slot: slotEar, Art: []string{"<o>"}},
+	{Name: "👓 Glasses", Kind: itemKindAccessory, Price: 35, Durable: true, Slot: slotEyes, Art: []string{"O"}},
+	{Name: "💊 Pill", Kind: itemKindMedicine, Price: 15, Health: 20},
+	{Name: "💉 Vaccine", Kind: itemKindMedicine, Price: 40, Health: 60},
+}
+
+// shopCatalog lists everything that can be bought at the given time: the
+// foods in season followed by toys and medicine.
+func shopCatalog(at time.Time) []Item {
+	return append(foodItems(foodCatalog(at)), availableItems...)
+}
+
+func foodItems(foods []Food) []Item {
+	items := make([]Item, 0, len(foods))
+	for _, food := range foods {
+		items = append(items, Item{Name: food.Name, Kind: itemKindFood, Price: food.Price})
+	}
+	return items
+}
+
+// findItem looks an item up by name, including foods out of season.
+func findItem(name string) (Item, bool) {
+	for _, item := range append(foodItems(allFoods()), availableItems...) {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+func starterInventory() map[string]int {
+	return map[string]int{
+		"🍎 Apple":  3,
+		"🥕 Carrot": 3,
+		"🍕 Pizza":  1,
+	}
+}
+
+func copyInventory(inventory map[string]int) map[string]int {
+	if inventory == nil {
+		return nil
+	}
+	inv := make(map[string]int, len(inventory))
+	for name, qty := range inventory {
+		inv[name] = qty
+	}
+	return inv
+}
+
+func (t *Tamagotchi) addItem(name string, qty int) {
+	if t.Inventory == nil {
+		t.Inventory = make(map[string]int)
+	}
+	t.Inventory[name] += qty
+}
+
+// takeItem removes one unit of an item from the inventory, reporting whether
+// there was one to take.
+func (t *Tamagotchi) takeItem(name string) bool {
+	if t.Inventory[name] <= 0 {
+		return false
+	}
+	t.Inventory[name]--
+	if t.Inventory[name] == 0 {
+		delete(t.Inventory, name)
+	}
+	return true
+}
+
+func (a *App) generateShopList(listShop *tview.List) {
+	listShop.Clear()
+
+	t, ok := a.tamagotchiSnapshot()
+	if !ok {
+		listShop.AddItem("No tamagotchi available.", "", 0, nil)
+		return
+	}
+
+	if !t.IsAlive {
+		listShop.AddItem("Your tamagotchi has passed away... 💔", "", 0, nil)
+		listShop.AddItem("The shop is closed for dead tamagotchis", "", 0, nil)
+		return
+	}
+
+	listShop.AddItem(fmt.Sprintf("💰 Coins: %d", t.Coins), "", 0, nil)
+	listShop.AddItem("", "", 0, nil) // Empty line
+
+	listShop.AddItem("=== FOR SALE ===", "", 0, nil)
+	for _, item := range shopCatalog(a.now()) {
+		item := item // Capture the item for the closure
+		kind := item.Kind
+		if item.Durable && item.Kind == itemKindToy {
+			kind = fmt.Sprintf("durable toy, +%d happiness", item.Comfort)
+		}
+		listShop.AddItem(
+			fmt.Sprintf("%s (%s, %d coins, owned: %d)", item.Name, kind, item.Price, t.Inventory[item.Name]),
+			"",
+			0,
+			func() { a.buyItem(item) },
+		)
+	}
+
+	listShop.AddItem("", "", 0, nil) // Empty line
+	listShop.AddItem("=== MEDICINE CABINET ===", "", 0, nil)
+	hasMedicine := false
+	for _, item := range availableItems {
+		if item.Kind != itemKindMedicine || t.Inventory[item.Name] <= 0 {
+			continue
+		}
+		hasMedicine = true
+		item := item // Capture the item for the closure
+		listShop.AddItem(
+			fmt.Sprintf("Use %s x%d (Health: +%d)", item.Name, t.Inventory[item.Name], item.Health),
+			"",
+			0,
+			func() { a.useMedicine(item) },
+		)
+	}
+	if !hasMedicine {
+		listShop.AddItem("No medicine. Buy some above when your tamagotchi is sick.", "", 0, nil)
+	}
+
+	listShop.AddItem("", "", 0, nil) // Empty line
+	listShop.AddItem("=== JOBS ===", "", 0, nil)
+	if t.Stage != "adult" {
+		listShop.AddItem("Only adults can work. Earn coins by playing games meanwhile!", "", 0, nil)
+		return
+	}
+	for i, job := range availableJobs {
+		jobIndex := i // Capture the index for the closure
+		requirement := ""
+		if job.Skill != "" {
+			requirement = fmt.Sprintf(" [needs %s %d]", job.Skill, job.MinSkill)
+			if !t.qualifiesFor(job) {
+				requirement += " 🔒"
+			}
+		}
+		listShop.AddItem(
+			fmt.Sprintf("%s%s (Pay: %d coins, Energy: %d, Happiness: %d, Hunger: +%d)",
+				job.Name, requirement, job.Pay, job.Energy, job.Happiness, job.Hunger),
+			"",
+			0,
+			func() { a.workJob(jobIndex) },
+		)
+	}
+}
+
+func (a *App) buyItem(item Item) {
+	if a.refuseAsClient("SHOP") {
+		return
+	}
+
+	a.stateMu.Lock()
+	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+		a.stateMu.Unlock()
+		return
+	}
+
+	if a.currentTamagotchi.Coins < item.Price {
+		a.stateMu.Unlock()
+		a.addGameEvent("SHOP", fmt.Sprintf("Not enough coins for %s (%d needed)", item.Name, item.Price))
+		return
+	}
+
+	if item.Durable && a.currentTamagotchi.Inventory[item.Name] > 0 {
+		a.stateMu.Unlock()
+		return
+	}
+
+	a.currentTamagotchi.Coins -= item.Price
+	a.currentTamagotchi.addItem(item.Name, 1)
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.addGameEvent("SHOP", fmt.Sprintf("Bought %s for %d coins", item.Name, item.Price))
+}
+
+func (a *App) useMedicine(item Item) {
+	if a.refuseAsClient("HEAL") {
+		return
+	}
+
+	a.stateMu.Lock()
+	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+		a.stateMu.Unlock()
+		return
+	}
+
+	if !a.currentTamagotchi.takeItem(item.Name) {
+		a.stateMu.Unlock()
+		return
+	}
+
+	a.currentTamagotchi.Health = min(100, a.currentTamagotchi.Health+item.Health)
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.addGameEvent("HEAL", fmt.Sprintf("Used %s! Health +%d", item.Name, item.Health))
+}
+
+func (a *App) shopPage() (title string, content tview.Primitive) {
+	listShop := a.viewsList["shop"]
+	if listShop == nil {
+		listShop = getList()
+		a.viewsList["shop"] = listShop
+	}
+
+	a.generateShopList(listShop)
+
+	title = shopSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listShop, 0, 1, true), 0, 1, true)
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"math"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+const (
+	skillIntelligence = "intelligence"
+	skillStrength     = "strength"
+	skillCreativity   = "creativity"
+
+	// Skills start fading once they haven't been trained for skillGracePeriod,
+	// losing skillDecayPerTick every tick (roughly 6 points a day).
+	skillGracePeriod  = 24 * time.Hour
+	skillDecayPerTick = 0.002
+
+	// adultFormSkill is the skill level needed to grow into a specialised
+	// adult form instead of a plain adult.
+	adultFormSkill = 50
+)
+
+var skillNames = []string{skillIntelligence, skillStrength, skillCreativity}
+
+type Skill struct {
+	Level       float64 // 0-100
+	LastTrained time.Time
+}
+
+// adultForms lists the specialised adults in priority order, each gated
+// behind one skill.
+var adultForms = []struct {
+	Form  string
+	Skill string
+}{
+	{Form: "scholar", Skill: skillIntelligence},
+	{Form: "athlete", Skill: skillStrength},
+	{Form: "artist", Skill: skillCreativity},
+}
+
+func skillsFromConfig(skills map[string]config.SkillConfig) map[string]Skill {
+	if skills == nil {
+		return nil
+	}
+	out := make(map[string]Skill, len(skills))
+	for name, s := range skills {
+		out[name] = Skill{Level: s.Level, LastTrained: s.LastTrained}
+	}
+	return out
+}
+
+func skillsToConfig(skills map[string]Skill) map[string]config.SkillConfig {
+	if skills == nil {
+		return nil
+	}
+	out := make(map[string]config.SkillConfig, len(skills))
+	for name, s := range skills {
+		out[name] = config.SkillConfig{Level: s.Level, LastTrained: s.LastTrained}
+	}
+	return out
+}
+
+func copySkills(skills map[string]Skill) map[string]Skill {
+	if skills == nil {
+		return nil
+	}
+	out := make(map[string]Skill, len(skills))
+	for name, s := range skills {
+		out[name] = s
+	}
+	return out
+}
+
+func (t *Tamagotchi) skillLevel(name string) int {
+	return int(t.Skills[name].Level)
+}
+
+func (t *Tamagotchi) trainSkill(name string, gain float64, now time.Time) {
+	if name == "" || gain <= 0 {
+		return
+	}
+	if t.Skills == nil {
+		t.Skills = make(map[string]Skill)
+	}
+	s := t.Skills[name]
+	s.Level = math.Min(100, s.Level+gain)
+	s.LastTrained = now
+	t.Skills[name] = s
+}
+
+// decaySkills slowly erodes skills that have been neglected for longer than
+// the grace period.
+func (t *Tamagotchi) decaySkills(now time.Time) {
+	for name, s := range t.Skills {
+		if s.Level <= 0 || now.Sub(s.LastTrained) < skillGracePeriod {
+			continue
+		}
+		s.Level = math.Max(0, s.Level-skillDecayPerTick)
+		t.Skills[name] = s
+	}
+}
+
+// adultForm picks the adult a tamagotchi grows into from its skills.
+func (t *Tamagotchi) adultForm() string {
+	for _, f := range adultForms {
+		if t.skillLevel(f.Skill) >= adultFormSkill {
+			return f.Form
+		}
+	}
+	return ""
+}
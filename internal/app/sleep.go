@@ -8,11 +8,11 @@ import (
 )
 
 type SleepOption struct {
-	Name       string
-	Duration   time.Duration
-	EnergyGain int
-	HealthGain int
-	Happiness  int
+	Name       string        `json:"name"`
+	Duration   time.Duration `json:"-"` // part of the name
+	EnergyGain int           `json:"energy_gain"`
+	HealthGain int           `json:"health_gain"`
+	Happiness  int           `json:"happiness"`
 }
 
 var sleepOptions = []SleepOption{
@@ -70,24 +70,50 @@ func (a *App) putTamagotchiToSleep(sleepIndex int) {
 	if sleepIndex < 0 || sleepIndex >= len(sleepOptions) {
 		return
 	}
+	a.act(methodSleep, actionParams{Option: sleepOptions[sleepIndex].Name})
+}
 
-	sleep := sleepOptions[sleepIndex]
-	now := time.Now()
+// sleepPet puts the named pet, or the current one, to sleep.
+func (a *App) sleepPet(pet, name string) error {
+	sleep, ok := findSleepOption(name)
+	if !ok {
+		return fmt.Errorf("unknown sleep option %q", name)
+	}
+
+	now := a.now()
 
 	a.stateMu.Lock()
-	if a.currentTamagotchi == nil || !a.currentTamagotchi.IsAlive {
+	t, err := a.livingPetLocked(pet)
+	if err != nil {
 		a.stateMu.Unlock()
-		return
+		return err
 	}
 
-	a.currentTamagotchi.Energy = min(100, a.currentTamagotchi.Energy+sleep.EnergyGain)
-	a.currentTamagotchi.Health = min(100, a.currentTamagotchi.Health+sleep.HealthGain)
-	a.currentTamagotchi.Happiness = min(100, a.currentTamagotchi.Happiness+sleep.Happiness)
-	a.currentTamagotchi.LastSleep = now
+	t.sleep(sleep, now)
+	pet = t.Name
+	quests := a.questActionLocked(t, questSleep, sleep.Name, now)
 	a.stateMu.Unlock()
 
 	a.updateConfigFromState()
-	a.addGameEvent("SLEEP", fmt.Sprintf("Slept for %s! Energy +%d, Health +%d", sleep.Name, sleep.EnergyGain, sleep.HealthGain))
+	a.addPetEvent(pet, "SLEEP", fmt.Sprintf("Slept for %s! Energy +%d, Health +%d", sleep.Name, sleep.EnergyGain, sleep.HealthGain))
+	a.announceQuests(pet, quests, now)
+	return nil
+}
+
+func findSleepOption(name string) (SleepOption, bool) {
+	for _, sleep := range sleepOptions {
+		if sleep.Name == name {
+			return sleep, true
+		}
+	}
+	return SleepOption{}, false
+}
+
+func (t *Tamagotchi) sleep(sleep SleepOption, now time.Time) {
+	t.Energy = min(100, t.Energy+sleep.EnergyGain)
+	t.Health = min(100, t.Health+sleep.HealthGain)
+	t.Happiness = min(100, t.Happiness+sleep.Happiness)
+	t.LastSleep = now
 }
 
 func (a *App) sleepPage() (title string, content tview.Primitive) {
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// statTiers describes how stat history is downsampled: every tick is kept
+// for an hour, five minute averages for a day and hourly averages for three
+// months.
+var statTiers = []struct {
+	Resolution time.Duration
+	Retention  time.Duration
+}{
+	{Resolution: 0, Retention: time.Hour},
+	{Resolution: 5 * time.Minute, Retention: 24 * time.Hour},
+	{Resolution: time.Hour, Retention: 90 * 24 * time.Hour},
+}
+
+// statSeries is the stat history of one pet, one tier per entry of
+// statTiers.
+type statSeries [][]config.StatSample
+
+func (s statSeries) add(t *Tamagotchi, at time.Time) statSeries {
+	return s.addSample(config.StatSample{
+		Hunger:    float64(t.Hunger),
+		Happiness: float64(t.Happiness),
+		Health:    float64(t.Health),
+		Energy:    float64(t.Energy),
+		Weight:    t.Weight,
+		Count:     1,
+	}, at)
+}
+
+// addSample adds a sample averaging sample.Count ticks, the last at at.
+func (s statSeries) addSample(sample config.StatSample, at time.Time) statSeries {
+	for len(s) < len(statTiers) {
+		s = append(s, nil)
+	}
+
+	for i, tier := range statTiers {
+		samples := s[i]
+
+		bucket := at
+		if tier.Resolution > 0 {
+			bucket = at.Truncate(tier.Resolution)
+		}
+
+		if n := len(samples); n > 0 && tier.Resolution > 0 && samples[n-1].Time.Equal(bucket) {
+			samples[n-1] = mergeSamples(samples[n-1], sample)
+		} else {
+			sample.Time = bucket
+			samples = append(samples, sample)
+		}
+
+		cutoff := at.Add(-tier.Retention)
+		drop := 0
+		for drop < len(samples) && samples[drop].Time.Before(cutoff) {
+			drop++
+		}
+		s[i] = samples[drop:]
+	}
+	return s
+}
+
+// mergeSamples folds a sample into a running average, each weighted by the
+// ticks it averages.
+func mergeSamples(sample, more config.StatSample) config.StatSample {
+	n, m := float64(sample.Count), float64(more.Count)
+	avg := func(old float64, v float64) float64 { return (old*n + v*m) / (n + m) }
+
+	sample.Hunger = avg(sample.Hunger, more.Hunger)
+	sample.Happiness = avg(sample.Happiness, more.Happiness)
+	sample.Health = avg(sample.Health, more.Health)
+	sample.Energy = avg(sample.Energy, more.Energy)
+	sample.Weight = avg(sample.Weight, more.Weight)
+	sample.Count += more.Count
+	return sample
+}
+
+// window returns the samples of the finest tier that covers the last d.
+func (s statSeries) window(d time.Duration, now time.Time) []config.StatSample {
+	for i, tier := range statTiers {
+		if tier.Retention < d || i >= len(s) {
+			continue
+		}
+		cutoff := now.Add(-d)
+		var out []config.StatSample
+		for _, sample := range s[i] {
+			if !sample.Time.Before(cutoff) {
+				out = append(out, sample)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func (a *App) recordStatsLocked(t *Tamagotchi, at time.Time) {
+	if a.stats == nil {
+		a.stats = make(map[string]statSeries)
+	}
+	a.stats[t.ID] = a.stats[t.ID].add(t, at)
+}
+
+func (a *App) statsWindow(petID string, d time.Duration) []config.StatSample {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+
+	return a.stats[petID].window(d, a.now())
+}
+
+func statsFromConfig(stats map[string][]config.StatTier) map[string]statSeries {
+	out := make(map[string]statSeries, len(stats))
+	for id, tiers := range stats {
+		series := make(statSeries, len(statTiers))
+		for i, tier := range statTiers {
+			for _, saved := range tiers {
+				if saved.Resolution == tier.Resolution {
+					series[i] = saved.Samples
+				}
+			}
+		}
+		out[id] = series
+	}
+	return out
+}
+
+// statsToConfigLocked copies the stat history of the pets still in the roster.
+func (a *App) statsToConfigLocked() map[string][]config.StatTier {
+	out := make(map[string][]config.StatTier, len(a.pets))
+	for _, t := range a.pets {
+		series := a.stats[t.ID]
+		tiers := make([]config.StatTier, 0, len(series))
+		for i, samples := range series {
+			tiers = append(tiers, config.StatTier{
+				Resolution: statTiers[i].Resolution,
+				Samples:    append([]config.StatSample(nil), samples...),
+			})
+		}
+		out[t.ID] = tiers
+	}
+	return out
+}
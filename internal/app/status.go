@@ -25,13 +25,18 @@ func (a *App) generateStatusList(listStatus *tview.List) {
 	if !t.IsAlive {
 		status = "🔴 Dead"
 	}
-	listStatus.AddItem(fmt.Sprintf("Status: %s", status), "", 0, nil)
+	listStatus.AddItem(fmt.Sprintf("Status: %s%s", status, modeBadge(t)), "", 0, nil)
 
 	// Basic info
 	listStatus.AddItem(fmt.Sprintf("Name: %s", t.Name), "", 0, nil)
 	listStatus.AddItem(fmt.Sprintf("Age: %d days", t.Age), "", 0, nil)
-	listStatus.AddItem(fmt.Sprintf("Stage: %s", t.Stage), "", 0, nil)
+	if t.Form != "" {
+		listStatus.AddItem(fmt.Sprintf("Stage: %s (%s)", t.Stage, t.Form), "", 0, nil)
+	} else {
+		listStatus.AddItem(fmt.Sprintf("Stage: %s", t.Stage), "", 0, nil)
+	}
 	listStatus.AddItem(fmt.Sprintf("Weight: %.1f grams", t.Weight), "", 0, nil)
+	listStatus.AddItem(fmt.Sprintf("Difficulty: %s", balanceName(t.Balance)), "", 0, nil)
 
 	// Stats with visual bars
 	listStatus.AddItem("", "", 0, nil) // Empty line
@@ -40,7 +45,7 @@ func (a *App) generateStatusList(listStatus *tview.List) {
 	// Hunger bar
 	hungerBar := a.createProgressBar(t.Hunger, 100)
 	hungerColor := "🟢"
-	if t.Hunger > 80 {
+	if t.Hunger > t.Balance.HungryAt {
 		hungerColor = "🔴"
 	} else if t.Hunger > 60 {
 		hungerColor = "🟡"
@@ -77,6 +82,21 @@ func (a *App) generateStatusList(listStatus *tview.List) {
 	}
 	listStatus.AddItem(fmt.Sprintf("Energy: %s %s", energyColor, energyBar), "", 0, nil)
 
+	// Skills
+	listStatus.AddItem("", "", 0, nil) // Empty line
+	listStatus.AddItem("=== SKILLS ===", "", 0, nil)
+	for _, name := range skillNames {
+		skillBar := a.createProgressBar(t.skillLevel(name), 100)
+		listStatus.AddItem(fmt.Sprintf("%s%s: %s", strings.ToUpper(name[:1]), name[1:], skillBar), "", 0, nil)
+	}
+
+	// Quests
+	listStatus.AddItem("", "", 0, nil) // Empty line
+	listStatus.AddItem("=== QUESTS ===", "", 0, nil)
+	for _, q := range t.activeQuests(a.now()) {
+		listStatus.AddItem(questLine(q), "", 0, nil)
+	}
+
 	// Last actions
 	listStatus.AddItem("", "", 0, nil) // Empty line
 	listStatus.AddItem("=== LAST ACTIONS ===", "", 0, nil)
@@ -92,7 +112,21 @@ func (a *App) generateStatusList(listStatus *tview.List) {
 		listStatus.AddItem("Time Alive: Unknown", "", 0, nil)
 	} else {
 		listStatus.AddItem(fmt.Sprintf("Created: %s", t.Created.Format("2006-01-02 15:04")), "", 0, nil)
-		listStatus.AddItem(fmt.Sprintf("Time Alive: %s", time.Since(t.Created).Round(time.Second)), "", 0, nil)
+		listStatus.AddItem(fmt.Sprintf("Time Alive: %s", a.now().Sub(t.Created).Round(time.Second)), "", 0, nil)
+	}
+	now := a.now()
+	s := season(now)
+	listStatus.AddItem(fmt.Sprintf("Season: %s %s%s", seasonIcons[s], strings.ToUpper(s[:1]), s[1:]), "", 0, nil)
+	for _, h := range t.holidaysOn(now) {
+		listStatus.AddItem(fmt.Sprintf("Today: 🎉 %s", h.Name), "", 0, nil)
+	}
+
+	if n := len(t.Care); n > 0 {
+		last := t.Care[n-1]
+		listStatus.AddItem("", "", 0, nil) // Empty line
+		listStatus.AddItem("=== CARE ===", "", 0, nil)
+		listStatus.AddItem(fmt.Sprintf("Score %s: %d/100 %s", last.Day.Format("Mon 01-02"), last.Score, careGrade(last.Score)), "", 0, nil)
+		listStatus.AddItem(fmt.Sprintf("Streak: %d days", careStreak(t.Care, t.StreakReset)), "", 0, nil)
 	}
 }
 
@@ -103,7 +137,11 @@ func (a *App) updateSpriteView(view *tview.TextView) {
 		return
 	}
 
-	view.SetText(renderTamagotchiSprite(t))
+	sprite := t.decorateSprite(renderTamagotchiSprite(t), a.now())
+	if t.Genes.Color != "" {
+		sprite = fmt.Sprintf("[%s]%s[-]", t.Genes.Color, sprite)
+	}
+	view.SetText(sprite)
 }
 
 func (a *App) createProgressBar(current, max int) string {
@@ -151,18 +189,124 @@ func renderTamagotchiSprite(t Tamagotchi) string {
 		stage = "egg"
 	}
 
+	var sprite string
 	switch stage {
 	case "egg":
-		return eggSprites[mood]
+		sprite = eggSprites[mood]
 	case "baby":
-		return babySprites[mood]
+		sprite = babySprites[mood]
 	case "child":
-		return childSprites[mood]
+		sprite = childSprites[mood]
 	case "teen":
-		return teenSprites[mood]
+		sprite = teenSprites[mood]
 	default:
-		return adultSprites[mood]
+		sprite = adultSprites[mood]
+	}
+
+	anchor, ok := spriteAnchors[stage]
+	if !ok {
+		// Eggs have nowhere to wear accessories.
+		return sprite
+	}
+
+	equipped := t.equippedItems()
+	if len(equipped) == 0 {
+		return sprite
+	}
+
+	grid := spriteGrid(sprite)
+	for _, item := range equipped {
+		switch item.Slot {
+		case slotHead:
+			grid = overlaySprite(grid, item.Art, anchor.headRow-len(item.Art), anchor.headCol-runeWidth(item.Art)/2)
+		case slotEar:
+			grid = overlaySprite(grid, item.Art, anchor.headRow, anchor.earCol)
+		case slotEyes:
+			lens := item.Art[0]
+			bridge := strings.Repeat("-", max(0, anchor.rightEye-anchor.leftEye-1))
+			grid = overlaySprite(grid, []string{lens + bridge + lens}, anchor.eyeRow, anchor.leftEye)
+		}
+	}
+
+	return joinSpriteGrid(grid)
+}
+
+// spriteAnchor marks where accessories attach on a stage's sprite, in rows
+// and columns of the sprite text.
+type spriteAnchor struct {
+	headRow  int // top row of the head
+	headCol  int // centre column of the head
+	earCol   int // first column right of the head
+	eyeRow   int
+	leftEye  int
+	rightEye int
+}
+
+var spriteAnchors = map[string]spriteAnchor{
+	"baby":  {headRow: 1, headCol: 4, earCol: 6, eyeRow: 2, leftEye: 3, rightEye: 4},
+	"child": {headRow: 1, headCol: 4, earCol: 7, eyeRow: 2, leftEye: 3, rightEye: 5},
+	"teen":  {headRow: 1, headCol: 5, earCol: 8, eyeRow: 2, leftEye: 4, rightEye: 6},
+	"adult": {headRow: 1, headCol: 6, earCol: 10, eyeRow: 2, leftEye: 4, rightEye: 8},
+}
+
+func spriteGrid(sprite string) [][]rune {
+	lines := strings.Split(sprite, "\n")
+	grid := make([][]rune, len(lines))
+	for i, line := range lines {
+		grid[i] = []rune(line)
+	}
+	return grid
+}
+
+// joinSpriteGrid pads every row to the same width so a centred view keeps
+// the overlays lined up with the sprite underneath.
+func joinSpriteGrid(grid [][]rune) string {
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = string(row) + strings.Repeat(" ", width-len(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// overlaySprite draws art onto the grid with its top-left corner at row, col.
+// Spaces in the art are transparent and the grid grows to fit the art.
+func overlaySprite(grid [][]rune, art []string, row, col int) [][]rune {
+	if row < 0 {
+		grid = append(make([][]rune, -row), grid...)
+		row = 0
+	}
+	col = max(0, col)
+
+	for i, line := range art {
+		r := row + i
+		for len(grid) <= r {
+			grid = append(grid, nil)
+		}
+		for j, ch := range []rune(line) {
+			if ch == ' ' {
+				continue
+			}
+			c := col + j
+			for len(grid[r]) <= c {
+				grid[r] = append(grid[r], ' ')
+			}
+			grid[r][c] = ch
+		}
+	}
+	return grid
+}
+
+func runeWidth(art []string) int {
+	width := 0
+	for _, line := range art {
+		width = max(width, len([]rune(line)))
 	}
+	return width
 }
 
 func spriteMood(happiness, health, energy int, alive bool) string {
@@ -1,8 +1,13 @@
 package app
 
-import "time"
+import (
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
 
 type Tamagotchi struct {
+	ID        string
 	Name      string
 	Age       int
 	Hunger    int
@@ -11,23 +16,51 @@ type Tamagotchi struct {
 	Energy    int
 	Weight    float64
 	Stage     string
+	Form      string // specialised adult form, if any
 	Created   time.Time
 	LastFed   time.Time
 	LastPlay  time.Time
 	LastSleep time.Time
 	IsAlive   bool
+	Coins     int
+	Inventory map[string]int
+	Equipped  map[string]string
+	Skills    map[string]Skill
+
+	Genes      Genes
+	Parents    []string // IDs of the parents, empty for a first generation pet
+	Generation int
+	LastBred   time.Time
+
+	Peak Peak
+
+	Care []CareDay // daily care scores, oldest first
+
+	Quests []Quest // quests of the current day and week
+
+	Celebrated map[string]int // holiday ID -> last year it was celebrated
+
+	StreakReset time.Time // care streaks only count days from here on
+
+	Balance  config.BalanceConfig // fixed when the pet is created
+	Hardcore bool                 // permadeath, see hardcore.go
+	Tampered bool                 // the save or the clock was tampered with, see integrity.go
+	Sick     bool                 // lost health on its last tick
+	tickDebt time.Duration        // game time since the pet's last tick, not saved
 }
 
 type GameEvent struct {
-	Type      string
-	Message   string
-	Timestamp time.Time
+	Pet       string    `json:"pet"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 type Food struct {
-	Name       string
-	Nutrition  int
-	Happiness  int
-	Energy     int
-	WeightGain float64
+	Name       string  `json:"name"`
+	Nutrition  int     `json:"nutrition"`
+	Happiness  int     `json:"happiness"`
+	Energy     int     `json:"energy"`
+	WeightGain float64 `json:"weight_gain"`
+	Price      int     `json:"price"`
 }
@@ -0,0 +1,432 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+const (
+	vacationFreeze = "freeze"
+	vacationSlow   = "slow"
+
+	// vacationSlowdown is how many times slower pets decay on a slow
+	// vacation.
+	vacationSlowdown = 4
+
+	// maxVacationDays is the longest vacation, and vacationsPerYear how many
+	// can be started in any 365 days.
+	maxVacationDays  = 14
+	vacationsPerYear = 4
+
+	defaultSitterFeedAt  = 70
+	defaultSitterSleepAt = 25
+)
+
+var (
+	vacationModes   = []string{vacationFreeze, vacationSlow}
+	vacationLengths = []int{1, 3, 7, 14} // days offered by the Vacation page
+
+	sitterFeedLevels  = []int{50, 60, 70, 80, 90}
+	sitterSleepLevels = []int{10, 20, 25, 30, 40}
+)
+
+// sitterSleep is the sleep the pet sitter gives a tired pet.
+var sitterSleep = sleepOptions[1]
+
+// onVacation reports whether the vacation covers the given time.
+func onVacation(v config.VacationConfig, at time.Time) bool {
+	return v.Mode != "" && !at.Before(v.Start) && at.Before(v.Until)
+}
+
+// vacationsLeft is how many more vacations can be started at the given time.
+func vacationsLeft(v config.VacationConfig, at time.Time) int {
+	used := 0
+	for _, start := range v.Taken {
+		if at.Sub(start) < 365*24*time.Hour {
+			used++
+		}
+	}
+	return max(0, vacationsPerYear-used)
+}
+
+// startVacation sends every pet on vacation for the given number of days.
+func startVacation(v *config.VacationConfig, mode string, days int, now time.Time) error {
+	if mode != vacationFreeze && mode != vacationSlow {
+		return fmt.Errorf("unknown vacation mode %q, want %s or %s", mode, vacationFreeze, vacationSlow)
+	}
+	if days < 1 || days > maxVacationDays {
+		return fmt.Errorf("a vacation lasts 1 to %d days", maxVacationDays)
+	}
+	if onVacation(*v, now) {
+		return fmt.Errorf("already on vacation until %s", v.Until.Format("2006-01-02 15:04"))
+	}
+	if vacationsLeft(*v, now) == 0 {
+		return fmt.Errorf("only %d vacations are allowed a year", vacationsPerYear)
+	}
+
+	v.Mode = mode
+	v.Start = now
+	v.Until = now.AddDate(0, 0, days)
+	v.Taken = append(v.Taken, now)
+	return nil
+}
+
+// endVacation cuts the vacation short. The pets are back from the next tick.
+func endVacation(v *config.VacationConfig, now time.Time) error {
+	if !onVacation(*v, now) {
+		return fmt.Errorf("not on vacation")
+	}
+	v.Until = now
+	return nil
+}
+
+func describeVacation(v config.VacationConfig) string {
+	how := "decay is frozen"
+	if v.Mode == vacationSlow {
+		how = fmt.Sprintf("decay is %d times slower", vacationSlowdown)
+	}
+	return fmt.Sprintf("On vacation until %s, %s", v.Until.Format("Mon 2006-01-02 15:04"), how)
+}
+
+// StartVacation sends the pets in cfg on vacation without starting the game,
+// returning the message to log for each of them.
+func StartVacation(cfg *config.Config, mode string, days int, now time.Time) (string, error) {
+	if pet, ok := livingHardcorePet(cfg.Pets); ok {
+		return "", fmt.Errorf("%s is a hardcore pet; hardcore pets can't go on vacation", pet)
+	}
+	if err := startVacation(&cfg.App.Vacation, mode, days, now); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Went on vacation (%s) until %s 🏖️", mode, cfg.App.Vacation.Until.Format("2006-01-02 15:04")), nil
+}
+
+// EndVacation cuts the vacation in cfg short.
+func EndVacation(cfg *config.Config, now time.Time) error {
+	return endVacation(&cfg.App.Vacation, now)
+}
+
+// VacationStatus describes the vacation in cfg and how many are left.
+func VacationStatus(cfg *config.Config, now time.Time) string {
+	return vacationStatus(cfg.App.Vacation, now)
+}
+
+func vacationStatus(v config.VacationConfig, now time.Time) string {
+	status := "At home"
+	if onVacation(v, now) {
+		status = describeVacation(v)
+	}
+	return fmt.Sprintf("%s. %d of %d vacations left this year.", status, vacationsLeft(v, now), vacationsPerYear)
+}
+
+// WithSitterDefaults fills in the thresholds of a sitter never set up.
+func WithSitterDefaults(s config.SitterConfig) config.SitterConfig {
+	if s.FeedAt == 0 {
+		s.FeedAt = defaultSitterFeedAt
+	}
+	if s.SleepAt == 0 {
+		s.SleepAt = defaultSitterSleepAt
+	}
+	return s
+}
+
+// DescribeSitter sums up the pet sitter's settings.
+func DescribeSitter(s config.SitterConfig) string {
+	if !s.Enabled {
+		return "Pet sitter: off"
+	}
+	buys := "uses the food in stock"
+	if s.BuyFood {
+		buys = "buys food with the pet's coins when out of stock"
+	}
+	return fmt.Sprintf("Pet sitter: on, feeds at hunger %d, puts to sleep at energy %d, %s", s.FeedAt, s.SleepAt, buys)
+}
+
+// vacationPausesLocked reports whether the tick at the given time is skipped
+// for a vacation. Slow vacations only keep every vacationSlowdown-th tick.
+func (a *App) vacationPausesLocked(at time.Time) bool {
+	if !onVacation(a.vacation, at) {
+		return false
+	}
+	if a.vacation.Mode == vacationFreeze {
+		return true
+	}
+	tick := at.UnixNano() / int64(a.tickIntervalLocked())
+	return tick%vacationSlowdown != 0
+}
+
+// vacationTickLocked brings the pets back once the vacation is over.
+func (a *App) vacationTickLocked(at time.Time) {
+	v := a.vacation
+	if v.Mode == "" || at.Before(v.Until) {
+		return
+	}
+
+	a.vacation.Mode = ""
+	for _, t := range a.pets {
+		if t.IsAlive {
+			a.addPetEventAt(t.Name, "VACATION", fmt.Sprintf("%s is back from a %s vacation! 🧳", t.Name, formatDuration(v.Until.Sub(v.Start))), at)
+		}
+	}
+}
+
+// sitterTickLocked has the pet sitter look after the pet during offline
+// catch-up, logging everything it does.
+func (a *App) sitterTickLocked(t *Tamagotchi, at time.Time) {
+	s := a.sitter
+	if !s.Enabled || !a.catchingUp || !t.IsAlive {
+		return
+	}
+
+	if t.Hunger >= s.FeedAt {
+		if food, bought, ok := a.sitterFood(t, at); ok {
+			t.eat(food, at)
+			message := fmt.Sprintf("The pet sitter fed %s %s.", t.Name, food.Name)
+			if bought {
+				message = fmt.Sprintf("The pet sitter bought %s for %d coins and fed %s.", food.Name, food.Price, t.Name)
+			}
+			a.addPetEventAt(t.Name, "SITTER", message, at)
+		} else if !a.sitterOutOfFood[t.ID] {
+			a.sitterOutOfFood[t.ID] = true
+			a.addPetEventAt(t.Name, "SITTER", fmt.Sprintf("The pet sitter ran out of food for %s!", t.Name), at)
+		}
+	}
+
+	if t.Energy <= s.SleepAt {
+		t.sleep(sitterSleep, at)
+		a.addPetEventAt(t.Name, "SITTER", fmt.Sprintf("The pet sitter put %s to bed.", t.Name), at)
+	}
+}
+
+// sitterFood takes the first food the pet has in stock or, if the sitter may,
+// buys the affordable one with the most nutrition per coin. It reports
+// whether it was bought.
+func (a *App) sitterFood(t *Tamagotchi, at time.Time) (Food, bool, bool) {
+	for _, food := range t.feedableFoods(at) {
+		if t.takeItem(food.Name) {
+			return food, false, true
+		}
+	}
+	if !a.sitter.BuyFood {
+		return Food{}, false, false
+	}
+
+	var best *Food
+	for _, food := range foodCatalog(at) {
+		if food.Price > t.Coins {
+			continue
+		}
+		if best == nil || food.Nutrition*best.Price > best.Nutrition*food.Price {
+			food := food
+			best = &food
+		}
+	}
+	if best == nil {
+		return Food{}, false, false
+	}
+	t.Coins -= best.Price
+	return *best, true, true
+}
+
+// sitterHasFood reports whether sitterFood would find the pet something.
+func (a *App) sitterHasFood(t *Tamagotchi, at time.Time) bool {
+	for _, food := range t.feedableFoods(at) {
+		if t.Inventory[food.Name] > 0 {
+			return true
+		}
+	}
+	if !a.sitter.BuyFood {
+		return false
+	}
+	for _, food := range foodCatalog(at) {
+		if food.Price <= t.Coins {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) goOnVacation(days int) {
+	var err error
+	if a.remote != nil {
+		err = a.callDaemon(methodVacation, vacationParams{Action: vacationStart, Mode: a.vacationMode, Days: days})
+	} else {
+		err = a.takeVacation(a.vacationMode, days)
+	}
+	if err != nil {
+		a.addGameEvent("VACATION", fmt.Sprintf("Can't go on vacation: %v", err))
+	}
+}
+
+// takeVacation sends every pet on vacation, logging it for each of them.
+func (a *App) takeVacation(mode string, days int) error {
+	now := a.now()
+
+	a.stateMu.Lock()
+	var err error
+	if pet, ok := a.livingHardcorePetLocked(); ok {
+		err = fmt.Errorf("%s is a hardcore pet; hardcore pets can't go on vacation", pet)
+	} else {
+		err = startVacation(&a.vacation, mode, days, now)
+	}
+	var pets []string
+	for _, t := range a.pets {
+		if t.IsAlive {
+			pets = append(pets, t.Name)
+		}
+	}
+	a.stateMu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	a.updateConfigFromState()
+	for _, pet := range pets {
+		a.addPetEvent(pet, "VACATION", fmt.Sprintf("Went on vacation (%s) for %d days 🏖️", mode, days))
+	}
+	return nil
+}
+
+func (a *App) comeBackFromVacation() {
+	var err error
+	if a.remote != nil {
+		err = a.callDaemon(methodVacation, vacationParams{Action: vacationEnd})
+	} else {
+		err = a.cutVacationShort()
+	}
+	if err != nil {
+		a.addGameEvent("VACATION", fmt.Sprintf("Can't come back from vacation: %v", err))
+	}
+}
+
+func (a *App) cutVacationShort() error {
+	a.stateMu.Lock()
+	err := endVacation(&a.vacation, a.now())
+	a.stateMu.Unlock()
+	if err != nil {
+		return err
+	}
+	a.updateConfigFromState()
+	a.requestRefresh()
+	return nil
+}
+
+// updateSitter changes the pet sitter's settings.
+func (a *App) updateSitter(change func(s *config.SitterConfig)) {
+	if a.remote != nil {
+		a.stateMu.RLock()
+		sitter := a.sitter
+		a.stateMu.RUnlock()
+		change(&sitter)
+		if err := a.callDaemon(methodSitter, sitter); err != nil {
+			a.addGameEvent("SITTER", fmt.Sprintf("Can't change the pet sitter: %v", err))
+		}
+		return
+	}
+
+	a.stateMu.Lock()
+	change(&a.sitter)
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.requestRefresh()
+}
+
+func (a *App) generateVacationList(listVacation *tview.List) {
+	listVacation.Clear()
+
+	now := a.now()
+	a.stateMu.RLock()
+	v := a.vacation
+	s := a.sitter
+	a.stateMu.RUnlock()
+
+	listVacation.AddItem("=== VACATION ===", "", 0, nil)
+	listVacation.AddItem(fmt.Sprintf("%d of %d vacations left this year, up to %d days each", vacationsLeft(v, now), vacationsPerYear, maxVacationDays), "", 0, nil)
+	listVacation.AddItem("", "", 0, nil) // Empty line
+
+	if onVacation(v, now) {
+		listVacation.AddItem(fmt.Sprintf("🏖️ %s", describeVacation(v)), "", 0, nil)
+		listVacation.AddItem("🏠 Come back now", "", 0, a.comeBackFromVacation)
+	} else {
+		for _, mode := range vacationModes {
+			mode := mode // Capture the mode for the closure
+			marker := "○"
+			if mode == a.vacationMode {
+				marker = "●"
+			}
+			label := "Freeze: stats stay as they are"
+			if mode == vacationSlow {
+				label = fmt.Sprintf("Slow: stats change %d times slower", vacationSlowdown)
+			}
+			listVacation.AddItem(fmt.Sprintf("%s %s", marker, label), "", 0, func() {
+				a.vacationMode = mode
+				a.generateVacationList(listVacation)
+			})
+		}
+		for _, days := range vacationLengths {
+			days := days // Capture the length for the closure
+			listVacation.AddItem(fmt.Sprintf("🏖️ Go on vacation for %d days", days), "", 0, func() { a.goOnVacation(days) })
+		}
+	}
+
+	listVacation.AddItem("", "", 0, nil) // Empty line
+	listVacation.AddItem("=== PET SITTER ===", "", 0, nil)
+	listVacation.AddItem("Looks after your pets while the game is closed.", "", 0, nil)
+	listVacation.AddItem("", "", 0, nil) // Empty line
+
+	enabled := "off"
+	if s.Enabled {
+		enabled = "on"
+	}
+	listVacation.AddItem(fmt.Sprintf("Pet sitter: %s", enabled), "", 0, func() {
+		a.updateSitter(func(s *config.SitterConfig) {
+			*s = WithSitterDefaults(*s)
+			s.Enabled = !s.Enabled
+		})
+	})
+	if !s.Enabled {
+		return
+	}
+	listVacation.AddItem(fmt.Sprintf("Feeds when hunger reaches %d", s.FeedAt), "", 0, func() {
+		a.updateSitter(func(s *config.SitterConfig) { s.FeedAt = nextLevel(sitterFeedLevels, s.FeedAt) })
+	})
+	listVacation.AddItem(fmt.Sprintf("Puts to sleep when energy drops to %d", s.SleepAt), "", 0, func() {
+		a.updateSitter(func(s *config.SitterConfig) { s.SleepAt = nextLevel(sitterSleepLevels, s.SleepAt) })
+	})
+	buys := "no"
+	if s.BuyFood {
+		buys = "yes"
+	}
+	listVacation.AddItem(fmt.Sprintf("Buys food with coins when out of stock: %s", buys), "", 0, func() {
+		a.updateSitter(func(s *config.SitterConfig) { s.BuyFood = !s.BuyFood })
+	})
+}
+
+// nextLevel cycles through levels, starting over after the last one.
+func nextLevel(levels []int, current int) int {
+	for i, level := range levels {
+		if level > current {
+			return levels[i]
+		}
+	}
+	return levels[0]
+}
+
+func (a *App) vacationPage() (title string, content tview.Primitive) {
+	listVacation := a.viewsList["vacation"]
+	if listVacation == nil {
+		listVacation = getList()
+		a.viewsList["vacation"] = listVacation
+	}
+
+	a.generateVacationList(listVacation)
+
+	title = vacationSection
+	return title, tview.NewFlex().
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(listVacation, 0, 1, true), 0, 1, true)
+}
@@ -0,0 +1,361 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// Webhooks post the types of event each asks for while the daemon or the game
+// is running, as JSON from a chat preset or the player's template. Events are
+// dropped in an inbox as they are logged, from the offline catch-up on, so
+// logging never waits on a post. Posts wait in a queue saved next to the
+// config, so none are lost to a restart, and the ones that fail are retried
+// with backoff.
+
+const (
+	webhookSlack   = "slack"
+	webhookDiscord = "discord"
+
+	// webhookCheckInterval is how often the queue is looked at for posts due
+	// a retry.
+	webhookCheckInterval = 5 * time.Second
+
+	// webhookTimeout is how long a webhook has to answer a post.
+	webhookTimeout = 10 * time.Second
+
+	// Failed posts are retried after webhookFirstRetry, twice as long after
+	// each failure up to webhookMaxRetry, and dropped after
+	// webhookMaxAttempts.
+	webhookFirstRetry  = 30 * time.Second
+	webhookMaxRetry    = time.Hour
+	webhookMaxAttempts = 10
+)
+
+// webhookPresets are the templates of chat services' incoming webhooks.
+var webhookPresets = map[string]string{
+	webhookSlack:   `{"text": {{json (printf "%s *%s*: %s" .Icon .Pet .Message)}}}`,
+	webhookDiscord: `{"username": "Termagotchi", "content": {{json (printf "%s **%s**: %s" .Icon .Pet .Message)}}}`,
+}
+
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// webhookEvent is what webhook templates are given.
+type webhookEvent struct {
+	Pet       string
+	Type      string
+	Message   string
+	Icon      string
+	Timestamp time.Time
+}
+
+// renderWebhook makes the body a webhook posts for an event: its template,
+// its preset's, or the event as JSON.
+func renderWebhook(h config.WebhookConfig, event GameEvent) (json.RawMessage, error) {
+	text := h.Template
+	if text == "" {
+		text = webhookPresets[h.Preset]
+	}
+	if text == "" {
+		return json.Marshal(event)
+	}
+
+	tmpl, err := template.New(h.Name).Funcs(webhookFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	data := webhookEvent{
+		Pet:       event.Pet,
+		Type:      event.Type,
+		Message:   event.Message,
+		Icon:      eventIcon(event.Type),
+		Timestamp: event.Timestamp,
+	}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	if !json.Valid(b.Bytes()) {
+		return nil, fmt.Errorf("webhook %s: the template doesn't make valid JSON: %s", h.Name, b.String())
+	}
+	return b.Bytes(), nil
+}
+
+// ValidateWebhook checks a webhook given by the player.
+func ValidateWebhook(h config.WebhookConfig) error {
+	if h.Name == "" {
+		return errors.New("a webhook needs a name")
+	}
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook %s: %q isn't an http or https URL", h.Name, h.URL)
+	}
+	if len(h.Events) == 0 {
+		return fmt.Errorf("webhook %s: no event types to post", h.Name)
+	}
+	for _, t := range h.Events {
+		if !slices.Contains(eventTypes, t) {
+			return fmt.Errorf("webhook %s: unknown event type %q", h.Name, t)
+		}
+	}
+	if _, ok := webhookPresets[h.Preset]; h.Preset != "" && !ok {
+		return fmt.Errorf("webhook %s: unknown preset %q, want %s or %s", h.Name, h.Preset, webhookSlack, webhookDiscord)
+	}
+	_, err = renderWebhook(h, testWebhookEvent(h))
+	return err
+}
+
+// ValidateWebhooks checks every webhook, and that their names are unique.
+func ValidateWebhooks(hooks []config.WebhookConfig) error {
+	names := make(map[string]bool)
+	for _, h := range hooks {
+		if err := ValidateWebhook(h); err != nil {
+			return err
+		}
+		if names[h.Name] {
+			return fmt.Errorf("there are two webhooks named %s", h.Name)
+		}
+		names[h.Name] = true
+	}
+	return nil
+}
+
+// DescribeWebhook sums up a webhook.
+func DescribeWebhook(h config.WebhookConfig) string {
+	format := "the event as JSON"
+	switch {
+	case h.Template != "":
+		format = "a template"
+	case h.Preset != "":
+		format = h.Preset
+	}
+	return fmt.Sprintf("%s: %s to %s as %s", h.Name, strings.Join(h.Events, ", "), h.URL, format)
+}
+
+func testWebhookEvent(h config.WebhookConfig) GameEvent {
+	event := GameEvent{Pet: "Termagotchi", Type: "DAEMON", Message: "Webhooks are working. 🔌", Timestamp: time.Now()}
+	if len(h.Events) > 0 {
+		event.Type = h.Events[0]
+	}
+	return event
+}
+
+// SendTestWebhook posts a test event to a webhook straight away.
+func SendTestWebhook(h config.WebhookConfig) error {
+	body, err := renderWebhook(h, testWebhookEvent(h))
+	if err != nil {
+		return err
+	}
+	return postWebhook(h.URL, body)
+}
+
+func postWebhook(target string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// webhookBackoff is how long to wait before trying a post again after its
+// attempts so far failed.
+func webhookBackoff(attempts int) time.Duration {
+	wait := webhookFirstRetry
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= webhookMaxRetry {
+			return webhookMaxRetry
+		}
+	}
+	return wait
+}
+
+func (a *App) webhooksSnapshot() []config.WebhookConfig {
+	a.stateMu.RLock()
+	defer a.stateMu.RUnlock()
+	return slices.Clone(a.webhooks)
+}
+
+// webhooksWant reports whether any webhook posts events of the given type.
+func (a *App) webhooksWant(eventType string) bool {
+	for _, h := range a.webhooksSnapshot() {
+		if slices.Contains(h.Events, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookInbox holds the events logged since the webhooks last looked. It
+// takes events once opened, which only the game that runs the webhooks does.
+type webhookInbox struct {
+	mu     sync.Mutex
+	opened bool
+	events []GameEvent
+	wake   chan struct{} // signalled when an event arrives
+}
+
+func newWebhookInbox() *webhookInbox {
+	return &webhookInbox{wake: make(chan struct{}, 1)}
+}
+
+func (in *webhookInbox) open() {
+	in.mu.Lock()
+	in.opened = true
+	in.mu.Unlock()
+}
+
+// add drops an event in the inbox. It never blocks.
+func (in *webhookInbox) add(event GameEvent) {
+	in.mu.Lock()
+	if !in.opened {
+		in.mu.Unlock()
+		return
+	}
+	in.events = append(in.events, event)
+	in.mu.Unlock()
+
+	select {
+	case in.wake <- struct{}{}:
+	default:
+	}
+}
+
+// take empties the inbox.
+func (in *webhookInbox) take() []GameEvent {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	events := in.events
+	in.events = nil
+	return events
+}
+
+// runWebhooks posts events to the webhooks for as long as the game runs.
+func (a *App) runWebhooks() {
+	queue, err := config.LoadWebhookQueue()
+	if err != nil {
+		log.Printf("failed to load webhook queue: %v", err)
+	}
+
+	ticker := time.NewTicker(webhookCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if queued := a.queueWebhooks(a.webhookInbox.take()); len(queued) > 0 {
+			queue = append(queue, queued...)
+			saveWebhookQueue(queue)
+		}
+
+		var changed bool
+		if queue, changed = a.deliverWebhooks(queue); changed {
+			saveWebhookQueue(queue)
+		}
+
+		select {
+		case <-a.webhookInbox.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueWebhooks makes the posts of events for the webhooks that want them.
+func (a *App) queueWebhooks(events []GameEvent) []config.WebhookDelivery {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var queued []config.WebhookDelivery
+	hooks := a.webhooksSnapshot()
+	now := a.now()
+	for _, event := range events {
+		for _, h := range hooks {
+			if !slices.Contains(h.Events, event.Type) {
+				continue
+			}
+			body, err := renderWebhook(h, event)
+			if err != nil {
+				log.Printf("failed to render webhook: %v", err)
+				continue
+			}
+			queued = append(queued, config.WebhookDelivery{Webhook: h.Name, URL: h.URL, Body: body, Queued: now, Next: now})
+		}
+	}
+	return queued
+}
+
+// deliverWebhooks posts the queued posts that are due, and returns those
+// still to go and whether any went.
+func (a *App) deliverWebhooks(queue []config.WebhookDelivery) ([]config.WebhookDelivery, bool) {
+	hooks := a.webhooksSnapshot()
+	now := a.now()
+	changed := false
+	pending := queue[:0]
+	for _, d := range queue {
+		if !slices.ContainsFunc(hooks, func(h config.WebhookConfig) bool { return h.Name == d.Webhook }) {
+			changed = true // the webhook was removed
+			continue
+		}
+		if now.Before(d.Next) {
+			pending = append(pending, d)
+			continue
+		}
+
+		changed = true
+		err := postWebhook(d.URL, d.Body)
+		if err == nil {
+			continue
+		}
+		d.Attempts++
+		if d.Attempts >= webhookMaxAttempts {
+			log.Printf("giving up on webhook %s after %d attempts: %v", d.Webhook, d.Attempts, err)
+			continue
+		}
+		d.Next = now.Add(webhookBackoff(d.Attempts))
+		pending = append(pending, d)
+	}
+	return pending, changed
+}
+
+func saveWebhookQueue(queue []config.WebhookDelivery) {
+	if err := config.SaveWebhookQueue(queue); err != nil {
+		log.Printf("failed to save webhook queue: %v", err)
+	}
+}
+
+// updateWebhooks replaces the webhooks.
+func (a *App) updateWebhooks(hooks []config.WebhookConfig) {
+	a.stateMu.Lock()
+	a.webhooks = hooks
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+}
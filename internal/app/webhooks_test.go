@@ -0,0 +1,165 @@
+package app
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+)
+
+// flakyWebhook answers the first failures posts with an error, then accepts
+// them, keeping their bodies.
+type flakyWebhook struct {
+	mu       sync.Mutex
+	failures int
+	posts    [][]byte
+}
+
+func (f *flakyWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.posts = append(f.posts, body)
+	if len(f.posts) <= f.failures {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *flakyWebhook) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.posts)
+}
+
+func setWebhooks(a *App, hooks ...config.WebhookConfig) {
+	a.stateMu.Lock()
+	a.webhooks = hooks
+	a.stateMu.Unlock()
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 32 * time.Minute, time.Hour, time.Hour}
+	for i, w := range want {
+		if got := webhookBackoff(i + 1); got != w {
+			t.Errorf("webhookBackoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestWebhookRetriesAndPersistedQueue(t *testing.T) {
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := newTestClock(start)
+	a := newTestApp(t, clock)
+
+	const failures = 4
+	hook := &flakyWebhook{failures: failures}
+	srv := httptest.NewServer(hook)
+	defer srv.Close()
+	setWebhooks(a, config.WebhookConfig{Name: "test", URL: srv.URL, Events: []string{"DEATH"}})
+
+	queue := a.queueWebhooks([]GameEvent{{Pet: "Rex", Type: "DEATH", Message: "Rex has passed away", Timestamp: start}, {Pet: "Rex", Type: "FEED"}})
+	if len(queue) != 1 {
+		t.Fatalf("%d posts queued, want 1 for the DEATH event", len(queue))
+	}
+	body := queue[0].Body
+
+	// Each failure puts the next attempt off by the backoff; until then
+	// nothing is posted.
+	now := start
+	for attempt := 1; attempt <= failures; attempt++ {
+		var changed bool
+		if queue, changed = a.deliverWebhooks(queue); !changed || len(queue) != 1 {
+			t.Fatalf("attempt %d: changed %v, %d queued; want a change and the post still queued", attempt, changed, len(queue))
+		}
+		if d := queue[0]; d.Attempts != attempt || !d.Next.Equal(now.Add(webhookBackoff(attempt))) {
+			t.Fatalf("attempt %d: %d attempts, next at %s; want %d, next at %s", attempt, d.Attempts, d.Next, attempt, now.Add(webhookBackoff(attempt)))
+		}
+
+		saveWebhookQueue(queue)
+		loaded, err := config.LoadWebhookQueue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(loaded) != 1 || loaded[0].Attempts != attempt || !loaded[0].Next.Equal(queue[0].Next) || !bytes.Equal(loaded[0].Body, body) {
+			t.Fatalf("attempt %d: reloaded %+v, want %+v", attempt, loaded, queue)
+		}
+		queue = loaded
+
+		clock.set(queue[0].Next.Add(-time.Second))
+		if _, changed := a.deliverWebhooks(queue); changed || hook.count() != attempt {
+			t.Fatalf("attempt %d: posted again %d times before the backoff was up", attempt, hook.count()-attempt)
+		}
+		now = queue[0].Next
+		clock.set(now)
+	}
+
+	queue, changed := a.deliverWebhooks(queue)
+	if !changed || len(queue) != 0 {
+		t.Fatalf("final attempt: changed %v, %d queued; want it delivered", changed, len(queue))
+	}
+	if hook.count() != failures+1 {
+		t.Errorf("%d posts, want %d", hook.count(), failures+1)
+	}
+	for _, post := range hook.posts {
+		if !bytes.Equal(post, body) {
+			t.Errorf("posted %s, want %s", post, body)
+		}
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	clock := newTestClock(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	a := newTestApp(t, clock)
+	hook := &flakyWebhook{failures: webhookMaxAttempts + 1}
+	srv := httptest.NewServer(hook)
+	defer srv.Close()
+	setWebhooks(a, config.WebhookConfig{Name: "test", URL: srv.URL, Events: []string{"DEATH"}})
+
+	queue := a.queueWebhooks([]GameEvent{{Pet: "Rex", Type: "DEATH"}})
+	for i := 0; i < webhookMaxAttempts; i++ {
+		queue, _ = a.deliverWebhooks(queue)
+		if len(queue) > 0 {
+			clock.set(queue[0].Next)
+		}
+	}
+	if len(queue) != 0 || hook.count() != webhookMaxAttempts {
+		t.Errorf("%d queued after %d posts; want none after %d", len(queue), hook.count(), webhookMaxAttempts)
+	}
+}
+
+func TestWebhooksGetCatchUpEvents(t *testing.T) {
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := newTestClock(start)
+	a := newTestApp(t, clock)
+	a.save()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.App.Webhooks = []config.WebhookConfig{{Name: "test", URL: "http://127.0.0.1:1", Events: []string{"PROGRESS"}}}
+	cfg.App.CurrentLogin = start.Add(3 * time.Hour)
+	clock.set(cfg.App.CurrentLogin)
+
+	b := newApp(cfg, clock.now)
+	b.initializeStateFromConfig()
+	t.Cleanup(b.closeJournal)
+
+	// Nothing is running the webhooks yet, but the events wait for them.
+	queued := b.queueWebhooks(b.webhookInbox.take())
+	if len(queued) == 0 {
+		t.Fatal("no posts queued for the catch-up's PROGRESS events")
+	}
+	for _, d := range queued {
+		if d.Webhook != "test" || !bytes.Contains(d.Body, []byte("PROGRESS")) {
+			t.Errorf("queued %s to %s", d.Body, d.Webhook)
+		}
+	}
+}
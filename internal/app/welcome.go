@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	// welcomeBackAfter is how long the game has to be closed for the
+	// welcome back summary to be shown on launch.
+	welcomeBackAfter = time.Hour
+
+	// criticalHealth is the health at which a pet is gravely ill.
+	criticalHealth = 20
+
+	// gravelyIll starts the message of a pet whose health falls to
+	// criticalHealth; the summary looks for it to tell near deaths.
+	gravelyIll = "%s is gravely ill!"
+
+	// welcomeBackLines caps the events listed for each pet.
+	welcomeBackLines = 8
+)
+
+// welcomeEventTypes are the events the welcome back summary lists.
+var welcomeEventTypes = map[string]bool{
+	"EVOLUTION":   true,
+	"DEATH":       true,
+	"SICK":        true,
+	"QUEST":       true,
+	"HOLIDAY":     true,
+	"WORLD":       true,
+	"ACHIEVEMENT": true,
+}
+
+// welcomeBackReport sums up a catch-up of elapsed for each pet alive before
+// it, from the pets before and after and the events logged meanwhile.
+func welcomeBackReport(elapsed time.Duration, before, after []Tamagotchi, events []GameEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Welcome back! You were away for %s.\n", formatDuration(elapsed))
+
+	for _, old := range before {
+		if !old.IsAlive {
+			continue
+		}
+		pet := old
+		for _, t := range after {
+			if t.ID == old.ID {
+				pet = t
+				break
+			}
+		}
+
+		var lines []string
+		var fellIll time.Time
+		sitter, gravely := 0, false
+		for _, e := range events {
+			if e.Pet != old.Name {
+				continue
+			}
+			switch {
+			case e.Type == "SITTER":
+				sitter++
+				continue
+			case e.Type == "SICK" && strings.HasPrefix(e.Message, fmt.Sprintf(gravelyIll, old.Name)):
+				gravely = true
+			case e.Type == "SICK" && fellIll.IsZero():
+				fellIll = e.Timestamp
+			}
+			if welcomeEventTypes[e.Type] {
+				lines = append(lines, fmt.Sprintf("%s %s %s", e.Timestamp.Format("Jan 2 15:04"), eventIcons[e.Type], e.Message))
+			}
+		}
+		if len(lines) > welcomeBackLines {
+			more := len(lines) - welcomeBackLines + 1
+			lines = append(lines[:welcomeBackLines-1], fmt.Sprintf("...and %d more, see the event log", more))
+		}
+
+		fmt.Fprintf(&b, "\n%s\n", old.Name)
+		fmt.Fprintf(&b, "Hunger %d→%d  Happiness %d→%d  Energy %d→%d  Health %d→%d\n",
+			old.Hunger, pet.Hunger, old.Happiness, pet.Happiness, old.Energy, pet.Energy, old.Health, pet.Health)
+		if !fellIll.IsZero() {
+			fmt.Fprintf(&b, "Health started dropping at %s\n", fellIll.Format("Jan 2 15:04"))
+		}
+		if gravely && pet.IsAlive {
+			b.WriteString("Nearly died but pulled through\n")
+		}
+		if sitter > 0 {
+			fmt.Fprintf(&b, "The sitter stepped in %d times\n", sitter)
+		}
+		for _, line := range lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// showWelcomeBack shows what happened while the game was closed, then
+// yesterday's care report if careReport is set.
+func (a *App) showWelcomeBack(careReport bool) {
+	if a.modal != nil {
+		return // Modal already showing
+	}
+
+	modal := tview.NewModal().
+		SetText(a.welcomeBack).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.TApp.SetRoot(a.TLayout, true).SetFocus(a.TLayout)
+			a.modal = nil
+			a.welcomeBack = ""
+			if careReport {
+				a.showCareReport()
+			}
+		})
+
+	a.modal = modal
+	a.TApp.SetRoot(modal, true).SetFocus(modal)
+}
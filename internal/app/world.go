@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ezeoleaf/termagotchi/internal/config"
+	"github.com/rivo/tview"
+)
+
+// worldEvent is a happening waiting for the player to choose what to do.
+type worldEvent struct {
+	Event config.WorldEventConfig
+	Pet   string
+	At    time.Time
+}
+
+func (a *App) loadWorldEvents() {
+	events, err := config.LoadWorldEvents()
+	if err != nil {
+		log.Printf("failed to load world events: %v", err)
+	}
+	a.worldEvents = events
+	a.nextWorldEvent = a.Config.App.NextWorldEvent
+}
+
+// scheduleWorldEventLocked picks when the next event happens. Gaps between
+// events are exponentially distributed, so events are equally likely at any
+// moment whether the game is running or catching up.
+func (a *App) scheduleWorldEventLocked(after time.Time) {
+	gap := time.Duration(a.worldRand.ExpFloat64() * float64(a.worldEvents.MeanInterval))
+	if gap < time.Minute {
+		gap = time.Minute
+	}
+	a.nextWorldEvent = after.Add(gap)
+}
+
+// pickWorldEvent chooses an event with probability proportional to its
+// weight.
+func (a *App) pickWorldEvent() (config.WorldEventConfig, bool) {
+	total := 0
+	for _, e := range a.worldEvents.Events {
+		total += e.Weight
+	}
+	if total == 0 {
+		return config.WorldEventConfig{}, false
+	}
+
+	n := a.worldRand.IntN(total)
+	for _, e := range a.worldEvents.Events {
+		if n < e.Weight {
+			return e, true
+		}
+		n -= e.Weight
+	}
+	return config.WorldEventConfig{}, false
+}
+
+// worldEventTickLocked fires the world events due by at. Live events wait
+// for the player in a modal; during offline catch-up, and in the daemon, the
+// offline choice is taken on the spot, remembered for a summary when
+// catching up.
+func (a *App) worldEventTickLocked(at time.Time) {
+	if len(a.worldEvents.Events) == 0 {
+		return
+	}
+	if a.nextWorldEvent.IsZero() {
+		a.scheduleWorldEventLocked(at)
+		return
+	}
+
+	for !a.nextWorldEvent.After(at) {
+		when := a.nextWorldEvent
+		a.scheduleWorldEventLocked(when)
+
+		t := a.currentTamagotchi
+		if t == nil || !t.IsAlive {
+			continue
+		}
+		event, ok := a.pickWorldEvent()
+		if !ok {
+			continue
+		}
+
+		if a.catchingUp || a.headless || a.pendingWorldEvent != nil {
+			choice := event.Choices[event.OfflineChoice]
+			t.applyEffects(choice.Effects)
+			a.addPetEventAt(t.Name, "WORLD", fmt.Sprintf("%s %s", fillPet(event.Message, t.Name), fillPet(choice.Message, t.Name)), when)
+			if a.catchingUp {
+				a.offlineWorldEvents = append(a.offlineWorldEvents, event.Summary)
+			}
+			continue
+		}
+
+		a.pendingWorldEvent = &worldEvent{Event: event, Pet: t.Name, At: when}
+		a.addPetEventAt(t.Name, "WORLD", fillPet(event.Message, t.Name), when)
+	}
+}
+
+// resolveWorldEvent applies the player's choice for the pending event.
+func (a *App) resolveWorldEvent(choiceIndex int) {
+	a.stateMu.Lock()
+	pending := a.pendingWorldEvent
+	a.pendingWorldEvent = nil
+	if pending == nil || choiceIndex < 0 || choiceIndex >= len(pending.Event.Choices) {
+		a.stateMu.Unlock()
+		return
+	}
+	choice := pending.Event.Choices[choiceIndex]
+	t := a.findPetLocked(pending.Pet)
+	if t == nil || !t.IsAlive {
+		a.stateMu.Unlock()
+		return
+	}
+	t.applyEffects(choice.Effects)
+	a.stateMu.Unlock()
+
+	a.updateConfigFromState()
+	a.addPetEvent(pending.Pet, "WORLD", fmt.Sprintf("%s: %s", choice.Label, fillPet(choice.Message, pending.Pet)))
+}
+
+// showWorldEventModal asks the player what to do about the pending event.
+func (a *App) showWorldEventModal() {
+	if a.modal != nil {
+		return // Modal already showing
+	}
+
+	a.stateMu.RLock()
+	pending := a.pendingWorldEvent
+	a.stateMu.RUnlock()
+	if pending == nil {
+		return
+	}
+
+	labels := make([]string, 0, len(pending.Event.Choices))
+	for _, choice := range pending.Event.Choices {
+		labels = append(labels, choice.Label)
+	}
+
+	modal := tview.NewModal().
+		SetText(fillPet(pending.Event.Message, pending.Pet)).
+		AddButtons(labels).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonIndex < 0 {
+				buttonIndex = pending.Event.OfflineChoice // Escape
+			}
+			a.resolveWorldEvent(buttonIndex)
+			a.TApp.SetRoot(a.TLayout, true).SetFocus(a.TLayout)
+			a.modal = nil
+		})
+
+	a.modal = modal
+	a.TApp.SetRoot(modal, true).SetFocus(modal)
+}
+
+// summarizeOfflineWorldEvents logs one line for the events that happened
+// while the player was away.
+func (a *App) summarizeOfflineWorldEvents(pet string) {
+	a.stateMu.Lock()
+	summaries := a.offlineWorldEvents
+	a.offlineWorldEvents = nil
+	a.stateMu.Unlock()
+	if len(summaries) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+	var order []string
+	for _, s := range summaries {
+		if counts[s] == 0 {
+			order = append(order, s)
+		}
+		counts[s]++
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, s := range order {
+		if counts[s] > 1 {
+			s = fmt.Sprintf("%s (x%d)", s, counts[s])
+		}
+		parts = append(parts, s)
+	}
+	a.addPetEvent(pet, "WORLD", fmt.Sprintf("While you were away: %s.", strings.Join(parts, ", ")))
+}
+
+// applyEffects changes the pet's stats and belongings, keeping stats in
+// range.
+func (t *Tamagotchi) applyEffects(e config.EffectsConfig) {
+	t.Hunger = max(0, min(100, t.Hunger+e.Hunger))
+	t.Happiness = max(0, min(100, t.Happiness+e.Happiness))
+	t.Health = max(0, min(100, t.Health+e.Health))
+	t.Energy = max(0, min(100, t.Energy+e.Energy))
+	t.Weight = math.Max(10.0, t.Weight+e.Weight)
+	t.Coins = max(0, t.Coins+e.Coins)
+	if e.Item != "" {
+		t.addItem(e.Item, 1)
+	}
+}
+
+func fillPet(text, pet string) string {
+	return strings.ReplaceAll(text, "{pet}", pet)
+}
@@ -9,40 +9,248 @@ import (
 )
 
 type Config struct {
-	App        AppConfig        `yaml:"app"`
-	Tamagotchi TamagotchiConfig `yaml:"tamagotchi"`
+	App     AppConfig          `yaml:"app"`
+	Pets    []TamagotchiConfig `yaml:"pets"`
+	Lineage []LineageConfig    `yaml:"lineage"` // every pet ever raised, for the family tree
+
+	Achievements AchievementsConfig `yaml:"achievements"`
+
+	// Tamagotchi holds the single pet of saves from before the roster
+	// existed. It is moved into Pets on load and no longer written.
+	Tamagotchi TamagotchiConfig `yaml:"tamagotchi,omitempty"`
+
+	// Signature is the HMAC of the game state, see integrity.go, and
+	// SignatureCheck how it checked out on load. Saves that failed the check
+	// aren't signed again until the game has dealt with them.
+	Signature      string `yaml:"signature,omitempty"`
+	SignatureCheck string `yaml:"-"`
 }
 
 type AppConfig struct {
 	LastLogin     time.Time `yaml:"last_login"`
 	CurrentLogin  time.Time `yaml:"current_login"`
 	SaveDirectory string    `yaml:"save_directory"`
+	ActivePet     string    `yaml:"active_pet"`
+
+	NextWorldEvent time.Time `yaml:"next_world_event"`
+
+	Vacation VacationConfig `yaml:"vacation"`
+	Sitter   SitterConfig   `yaml:"sitter"`
+	Notify   NotifyConfig   `yaml:"notify"`
+
+	Webhooks []WebhookConfig `yaml:"webhooks"`
+
+	Difficulty string           `yaml:"difficulty"` // balance preset for new pets: relaxed, classic or hardcore
+	Balance    BalanceOverrides `yaml:"balance"`    // custom changes to the preset for new pets
+
+	Integrity IntegrityConfig `yaml:"integrity"`
+}
+
+// BalanceConfig is how fast a pet's stats decay and when they start to hurt.
+// Each pet keeps the balance it was created with.
+type BalanceConfig struct {
+	Preset string `yaml:"preset" json:"preset"`
+	Custom bool   `yaml:"custom" json:"custom"` // the preset was changed by overrides
+
+	UpdateInterval  time.Duration `yaml:"update_interval" json:"update_interval"`
+	HungerPerTick   int           `yaml:"hunger_per_tick" json:"hunger_per_tick"`
+	EnergyPerTick   int           `yaml:"energy_per_tick" json:"energy_per_tick"`
+	SadnessPerTick  int           `yaml:"sadness_per_tick" json:"sadness_per_tick"`   // happiness lost per tick while hungry
+	SicknessPerTick int           `yaml:"sickness_per_tick" json:"sickness_per_tick"` // health lost per tick while starving or miserable
+	HungryAt        int           `yaml:"hungry_at" json:"hungry_at"`                 // hunger above which happiness drops
+	StarvingAt      int           `yaml:"starving_at" json:"starving_at"`             // hunger above which health drops
+	MiserableAt     int           `yaml:"miserable_at" json:"miserable_at"`           // happiness below which health drops
+}
+
+// BalanceOverrides changes some values of a balance preset. Unset values keep
+// the preset's.
+type BalanceOverrides struct {
+	UpdateInterval  *time.Duration `yaml:"update_interval,omitempty"`
+	HungerPerTick   *int           `yaml:"hunger_per_tick,omitempty"`
+	EnergyPerTick   *int           `yaml:"energy_per_tick,omitempty"`
+	SadnessPerTick  *int           `yaml:"sadness_per_tick,omitempty"`
+	SicknessPerTick *int           `yaml:"sickness_per_tick,omitempty"`
+	HungryAt        *int           `yaml:"hungry_at,omitempty"`
+	StarvingAt      *int           `yaml:"starving_at,omitempty"`
+	MiserableAt     *int           `yaml:"miserable_at,omitempty"`
+}
+
+// VacationConfig is the current or last vacation. Every pet's decay stops,
+// or slows down, between Start and Until.
+type VacationConfig struct {
+	Mode  string      `yaml:"mode"` // freeze or slow; empty when not on vacation
+	Start time.Time   `yaml:"start"`
+	Until time.Time   `yaml:"until"`
+	Taken []time.Time `yaml:"taken"` // start of every vacation, for the yearly cap
+}
+
+// SitterConfig is the pet sitter that looks after the pets during offline
+// progress.
+type SitterConfig struct {
+	Enabled bool `yaml:"enabled"`
+	FeedAt  int  `yaml:"feed_at"`  // feeds when hunger reaches this
+	SleepAt int  `yaml:"sleep_at"` // puts to sleep when energy drops to this
+	BuyFood bool `yaml:"buy_food"` // buys food with the pet's coins when out of stock
+}
+
+// NotifyConfig is how the player is alerted when a pet needs attention while
+// the daemon or the game is running.
+type NotifyConfig struct {
+	Enabled    bool          `yaml:"enabled" json:"enabled"`
+	Backends   []string      `yaml:"backends" json:"backends"`       // bell, desktop and command
+	Command    string        `yaml:"command" json:"command"`         // shell command run by the command backend
+	HungerAt   int           `yaml:"hunger_at" json:"hunger_at"`     // alerts when hunger reaches this
+	HealthAt   int           `yaml:"health_at" json:"health_at"`     // alerts when health drops to this
+	QuietFrom  string        `yaml:"quiet_from" json:"quiet_from"`   // no alerts from this time of day, as 22:00...
+	QuietUntil string        `yaml:"quiet_until" json:"quiet_until"` // ...until this one
+	Every      time.Duration `yaml:"every" json:"every"`             // at most one alert of a kind per pet this often
+}
+
+// WebhookConfig posts some types of event to a URL, such as a chat
+// channel's.
+type WebhookConfig struct {
+	Name     string   `yaml:"name" json:"name"`
+	URL      string   `yaml:"url" json:"url"`
+	Events   []string `yaml:"events" json:"events"`     // event types to post, such as DEATH, EVOLUTION or ALERT
+	Preset   string   `yaml:"preset" json:"preset"`     // slack or discord; neither posts the event as is
+	Template string   `yaml:"template" json:"template"` // JSON body as a Go template of the event, instead of a preset
 }
 
 type TamagotchiConfig struct {
-	Name      string    `yaml:"name"`
-	Age       int       `yaml:"age"`
-	Hunger    int       `yaml:"hunger"`    // 0-100, 0 = full, 100 = starving
-	Happiness int       `yaml:"happiness"` // 0-100, 0 = very sad, 100 = very happy
-	Health    int       `yaml:"health"`    // 0-100, 0 = sick, 100 = healthy
-	Energy    int       `yaml:"energy"`    // 0-100, 0 = tired, 100 = energetic
-	Weight    float64   `yaml:"weight"`    // in grams
-	Stage     string    `yaml:"stage"`     // egg, baby, child, teen, adult
-	Created   time.Time `yaml:"created"`
-	LastFed   time.Time `yaml:"last_fed"`
-	LastPlay  time.Time `yaml:"last_play"`
-	LastSleep time.Time `yaml:"last_sleep"`
-	IsAlive   bool      `yaml:"is_alive"`
+	ID        string    `yaml:"id" json:"id"`
+	Name      string    `yaml:"name" json:"name"`
+	Age       int       `yaml:"age" json:"age"`
+	Hunger    int       `yaml:"hunger" json:"hunger"`       // 0-100, 0 = full, 100 = starving
+	Happiness int       `yaml:"happiness" json:"happiness"` // 0-100, 0 = very sad, 100 = very happy
+	Health    int       `yaml:"health" json:"health"`       // 0-100, 0 = sick, 100 = healthy
+	Energy    int       `yaml:"energy" json:"energy"`       // 0-100, 0 = tired, 100 = energetic
+	Weight    float64   `yaml:"weight" json:"weight"`       // in grams
+	Stage     string    `yaml:"stage" json:"stage"`         // egg, baby, child, teen, adult
+	Form      string    `yaml:"form" json:"form"`           // scholar, athlete, artist or empty for a plain adult
+	Created   time.Time `yaml:"created" json:"created"`
+	LastFed   time.Time `yaml:"last_fed" json:"last_fed"`
+	LastPlay  time.Time `yaml:"last_play" json:"last_play"`
+	LastSleep time.Time `yaml:"last_sleep" json:"last_sleep"`
+	IsAlive   bool      `yaml:"is_alive" json:"is_alive"`
+
+	Coins     int               `yaml:"coins" json:"coins"`
+	Inventory map[string]int    `yaml:"inventory" json:"inventory"` // item name -> quantity owned
+	Equipped  map[string]string `yaml:"equipped" json:"equipped"`   // accessory slot -> item name
+
+	Skills map[string]SkillConfig `yaml:"skills" json:"skills"`
+
+	Genes      GenesConfig `yaml:"genes" json:"genes"`
+	Parents    []string    `yaml:"parents" json:"parents"` // parent IDs
+	Generation int         `yaml:"generation" json:"generation"`
+	LastBred   time.Time   `yaml:"last_bred" json:"last_bred"`
+
+	Peak PeakConfig `yaml:"peak" json:"peak"`
+
+	Care []CareDayConfig `yaml:"care" json:"care"` // daily care scores, oldest first
+
+	Quests []QuestConfig `yaml:"quests" json:"quests"` // quests of the current day and week
+
+	Celebrated map[string]int `yaml:"celebrated" json:"celebrated"` // holiday ID -> last year it was celebrated
+
+	Balance BalanceConfig `yaml:"balance" json:"balance"`
+
+	Hardcore bool   `yaml:"hardcore" json:"hardcore"` // permadeath: no restart or vacation while alive
+	Seal     string `yaml:"seal" json:"seal"`         // no longer written; kept so saves signed with a seal still match
+	Tampered bool   `yaml:"tampered" json:"tampered"` // the seal or the save's signature didn't match, or the clock jumped
+
+	StreakReset time.Time `yaml:"streak_reset" json:"streak_reset"` // care streaks only count days from here on
+	Sick        bool      `yaml:"sick" json:"sick"`                 // lost health on its last tick
 }
 
-func LoadConfig() (*Config, error) {
+// Mode is "hardcore" for permadeath pets and "normal" for the rest.
+func (p TamagotchiConfig) Mode() string {
+	if p.Hardcore {
+		return ModeHardcore
+	}
+	return ModeNormal
+}
+
+const (
+	ModeNormal   = "normal"
+	ModeHardcore = "hardcore"
+)
+
+type GenesConfig struct {
+	Color  string   `yaml:"color" json:"color"`
+	Traits []string `yaml:"traits" json:"traits"`
+}
+
+type LineageConfig struct {
+	ID         string    `yaml:"id"`
+	Name       string    `yaml:"name"`
+	Parents    []string  `yaml:"parents"`
+	Generation int       `yaml:"generation"`
+	Born       time.Time `yaml:"born"`
+	Color      string    `yaml:"color"`
+}
+
+type AchievementsConfig struct {
+	Unlocked     []UnlockConfig       `yaml:"unlocked"`
+	FoodsTried   []string             `yaml:"foods_tried"`
+	HealthySince map[string]time.Time `yaml:"healthy_since"` // pet ID -> when health last rose above 90
+}
+
+type UnlockConfig struct {
+	ID  string    `yaml:"id"`
+	Pet string    `yaml:"pet"` // who earned it
+	At  time.Time `yaml:"at"`
+}
+
+type SkillConfig struct {
+	Level       float64   `yaml:"level" json:"level"` // 0-100
+	LastTrained time.Time `yaml:"last_trained" json:"last_trained"`
+}
+
+type QuestConfig struct {
+	ID       string    `yaml:"id" json:"id"`
+	Period   string    `yaml:"period" json:"period"` // day or ISO week
+	Progress int       `yaml:"progress" json:"progress"`
+	Seen     []string  `yaml:"seen" json:"seen"`
+	Failed   bool      `yaml:"failed" json:"failed"`
+	Done     time.Time `yaml:"done" json:"done"`
+}
+
+type CareDayConfig struct {
+	Day      time.Time     `yaml:"day" json:"day"`
+	Score    int           `yaml:"score" json:"score"`       // 0-100
+	Healthy  float64       `yaml:"healthy" json:"healthy"`   // share of the day with stats in healthy ranges, 0-1
+	Response time.Duration `yaml:"response" json:"response"` // average time taken to answer a need
+	Needs    int           `yaml:"needs" json:"needs"`
+	Missed   int           `yaml:"missed" json:"missed"` // needs left unanswered
+}
+
+// Dir returns the directory holding the save files, creating it if needed.
+func Dir() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	appConfigDir := filepath.Join(configDir, "termagotchi")
 	if err := os.MkdirAll(appConfigDir, 0755); err != nil {
+		return "", err
+	}
+
+	return appConfigDir, nil
+}
+
+// SocketPath is where a running daemon listens for the game and the CLI.
+func SocketPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "daemon.sock"), nil
+}
+
+func LoadConfig() (*Config, error) {
+	appConfigDir, err := Dir()
+	if err != nil {
 		return nil, err
 	}
 
@@ -53,21 +261,7 @@ func LoadConfig() (*Config, error) {
 			CurrentLogin:  time.Now(),
 			SaveDirectory: appConfigDir,
 		},
-		Tamagotchi: TamagotchiConfig{
-			Name:      "Tammy",
-			Age:       0,
-			Hunger:    50,
-			Happiness: 50,
-			Health:    100,
-			Energy:    100,
-			Weight:    50.0,
-			Stage:     "egg",
-			Created:   time.Now(),
-			LastFed:   time.Now(),
-			LastPlay:  time.Now(),
-			LastSleep: time.Now(),
-			IsAlive:   true,
-		},
+		SignatureCheck: SignatureUnsigned,
 	}
 
 	if _, err := os.Stat(configPath); err == nil {
@@ -79,8 +273,16 @@ func LoadConfig() (*Config, error) {
 		if err := yaml.Unmarshal(data, cfg); err != nil {
 			return nil, err
 		}
+		cfg.SignatureCheck = cfg.checkSignature(appConfigDir)
 	}
 
+	// Move a single-pet save into the roster
+	if len(cfg.Pets) == 0 && cfg.Tamagotchi.Name != "" {
+		cfg.Pets = []TamagotchiConfig{cfg.Tamagotchi}
+		cfg.App.ActivePet = cfg.Tamagotchi.Name
+	}
+	cfg.Tamagotchi = TamagotchiConfig{}
+
 	// Update current login time
 	cfg.App.CurrentLogin = time.Now()
 
@@ -88,14 +290,22 @@ func LoadConfig() (*Config, error) {
 }
 
 func SaveConfig(cfg *Config) error {
-	configDir, err := os.UserConfigDir()
+	appConfigDir, err := Dir()
 	if err != nil {
 		return err
 	}
 
-	appConfigDir := filepath.Join(configDir, "termagotchi")
 	configPath := filepath.Join(appConfigDir, "config.yml")
 
+	cfg.Signature = ""
+	if cfg.SignatureCheck != SignatureInvalid {
+		key, err := installKey(appConfigDir, true)
+		if err != nil {
+			return err
+		}
+		cfg.Signature = cfg.sign(key)
+	}
+
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return err
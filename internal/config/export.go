@@ -0,0 +1,286 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportVersion is bumped whenever the export format changes incompatibly.
+const ExportVersion = 1
+
+// exportColumns are the CSV columns, in order. Every row fills the columns
+// that apply to its record kind and leaves the rest empty.
+var exportColumns = []string{
+	"record", "pet", "timestamp", "type", "message",
+	"resolution_seconds", "hunger", "happiness", "health", "energy", "weight", "samples",
+	"mode",
+}
+
+// Export is everything known about one pet over a period of time.
+type Export struct {
+	Version  int               `json:"version"`
+	Exported time.Time         `json:"exported"`
+	Mode     string            `json:"mode"`          // normal or hardcore
+	Pet      *TamagotchiConfig `json:"pet,omitempty"` // nil for pets that are no longer in the roster
+	Events   []EventRecord     `json:"events"`
+	Stats    []ExportStat      `json:"stats"`
+}
+
+// ExportStat is a stat sample together with the resolution it was kept at.
+type ExportStat struct {
+	Time       time.Time `json:"time"`
+	Resolution int64     `json:"resolution_seconds"` // 0 for single ticks
+	Hunger     float64   `json:"hunger"`
+	Happiness  float64   `json:"happiness"`
+	Health     float64   `json:"health"`
+	Energy     float64   `json:"energy"`
+	Weight     float64   `json:"weight"`
+	Samples    int       `json:"samples"`
+}
+
+// ExportStats flattens stat tiers into export rows, keeping the samples taken
+// between since and until. Zero times leave that end open.
+func ExportStats(tiers []StatTier, since, until time.Time) []ExportStat {
+	var out []ExportStat
+	for _, tier := range tiers {
+		for _, s := range tier.Samples {
+			if (!since.IsZero() && s.Time.Before(since)) || (!until.IsZero() && s.Time.After(until)) {
+				continue
+			}
+			out = append(out, ExportStat{
+				Time:       s.Time,
+				Resolution: int64(tier.Resolution / time.Second),
+				Hunger:     s.Hunger,
+				Happiness:  s.Happiness,
+				Health:     s.Health,
+				Energy:     s.Energy,
+				Weight:     s.Weight,
+				Samples:    s.Count,
+			})
+		}
+	}
+	return out
+}
+
+// StatTiers groups exported stat rows back into tiers by resolution, in the
+// order the resolutions first appear.
+func (e *Export) StatTiers() []StatTier {
+	var tiers []StatTier
+	index := make(map[int64]int)
+	for _, s := range e.Stats {
+		i, ok := index[s.Resolution]
+		if !ok {
+			i = len(tiers)
+			index[s.Resolution] = i
+			tiers = append(tiers, StatTier{Resolution: time.Duration(s.Resolution) * time.Second})
+		}
+		tiers[i].Samples = append(tiers[i].Samples, StatSample{
+			Time:      s.Time,
+			Hunger:    s.Hunger,
+			Happiness: s.Happiness,
+			Health:    s.Health,
+			Energy:    s.Energy,
+			Weight:    s.Weight,
+			Count:     s.Samples,
+		})
+	}
+	return tiers
+}
+
+// WriteExportJSON writes the export as an indented JSON document.
+func WriteExportJSON(w io.Writer, e *Export) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
+
+// ReadExportJSON reads an export written by WriteExportJSON.
+func ReadExportJSON(r io.Reader) (*Export, error) {
+	var e Export
+	if err := json.NewDecoder(r).Decode(&e); err != nil {
+		return nil, err
+	}
+	if e.Version > ExportVersion {
+		return nil, fmt.Errorf("export version %d is newer than this termagotchi understands", e.Version)
+	}
+	return &e, nil
+}
+
+// WriteExportCSV writes the export as CSV, one row per event or stat sample.
+// A leading "pet" row carries the pet itself as JSON in the message column so
+// the file can be imported again. Every row has the pet's mode.
+func WriteExportCSV(w io.Writer, e *Export) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+
+	if e.Pet != nil {
+		pet, err := json.Marshal(e.Pet)
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(e.exportRow(map[string]string{
+			"record":    "pet",
+			"pet":       e.Pet.Name,
+			"timestamp": formatExportTime(e.Pet.Created),
+			"message":   string(pet),
+		})); err != nil {
+			return err
+		}
+	}
+
+	for _, ev := range e.Events {
+		if err := cw.Write(e.exportRow(map[string]string{
+			"record":    "event",
+			"pet":       ev.Pet,
+			"timestamp": formatExportTime(ev.Timestamp),
+			"type":      ev.Type,
+			"message":   ev.Message,
+		})); err != nil {
+			return err
+		}
+	}
+
+	pet := ""
+	if e.Pet != nil {
+		pet = e.Pet.Name
+	}
+	for _, s := range e.Stats {
+		if err := cw.Write(e.exportRow(map[string]string{
+			"record":             "stat",
+			"pet":                pet,
+			"timestamp":          formatExportTime(s.Time),
+			"resolution_seconds": strconv.FormatInt(s.Resolution, 10),
+			"hunger":             formatExportFloat(s.Hunger),
+			"happiness":          formatExportFloat(s.Happiness),
+			"health":             formatExportFloat(s.Health),
+			"energy":             formatExportFloat(s.Energy),
+			"weight":             formatExportFloat(s.Weight),
+			"samples":            strconv.Itoa(s.Samples),
+		})); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadExportCSV reads an export written by WriteExportCSV. Columns are
+// matched by name, so extra or reordered columns are fine.
+func ReadExportCSV(r io.Reader) (*Export, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+	if _, ok := columns["record"]; !ok {
+		return nil, fmt.Errorf("not a termagotchi export: missing record column")
+	}
+
+	e := &Export{Version: ExportVersion}
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+
+		if err := e.addCSVRow(field); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return e, nil
+}
+
+func (e *Export) addCSVRow(field func(string) string) error {
+	ts, err := parseExportTime(field("timestamp"))
+	if err != nil {
+		return err
+	}
+
+	if mode := field("mode"); mode != "" {
+		e.Mode = mode
+	}
+
+	switch field("record") {
+	case "pet":
+		var pet TamagotchiConfig
+		if err := json.Unmarshal([]byte(field("message")), &pet); err != nil {
+			return err
+		}
+		e.Pet = &pet
+	case "event":
+		e.Events = append(e.Events, EventRecord{
+			Pet:       field("pet"),
+			Type:      field("type"),
+			Message:   field("message"),
+			Timestamp: ts,
+		})
+	case "stat":
+		var s ExportStat
+		s.Time = ts
+		if s.Resolution, err = strconv.ParseInt(field("resolution_seconds"), 10, 64); err != nil {
+			return err
+		}
+		for name, dst := range map[string]*float64{
+			"hunger":    &s.Hunger,
+			"happiness": &s.Happiness,
+			"health":    &s.Health,
+			"energy":    &s.Energy,
+			"weight":    &s.Weight,
+		} {
+			if *dst, err = strconv.ParseFloat(field(name), 64); err != nil {
+				return err
+			}
+		}
+		if s.Samples, err = strconv.Atoi(field("samples")); err != nil {
+			return err
+		}
+		e.Stats = append(e.Stats, s)
+	default:
+		return fmt.Errorf("unknown record %q", field("record"))
+	}
+	return nil
+}
+
+// exportRow lays out a CSV row, adding the export's mode.
+func (e *Export) exportRow(values map[string]string) []string {
+	values["mode"] = e.Mode
+	row := make([]string, len(exportColumns))
+	for i, name := range exportColumns {
+		row[i] = values[name]
+	}
+	return row
+}
+
+func formatExportTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseExportTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func formatExportFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GraveConfig is the memorial kept for a pet that died or was replaced.
+type GraveConfig struct {
+	ID         string     `yaml:"id"`
+	Name       string     `yaml:"name"`
+	Born       time.Time  `yaml:"born"`
+	Died       time.Time  `yaml:"died"`
+	Stage      string     `yaml:"stage"`
+	Form       string     `yaml:"form"`
+	Generation int        `yaml:"generation"`
+	Cause      string     `yaml:"cause"` // cause of death, or "replaced"
+	Peak       PeakConfig `yaml:"peak"`
+	Notable    []string   `yaml:"notable"` // memorable events, oldest first
+	Hardcore   bool       `yaml:"hardcore"`
+}
+
+// Mode is "hardcore" for permadeath pets and "normal" for the rest.
+func (g GraveConfig) Mode() string {
+	if g.Hardcore {
+		return ModeHardcore
+	}
+	return ModeNormal
+}
+
+// PeakConfig holds the best stats a pet ever reached.
+type PeakConfig struct {
+	Happiness int     `yaml:"happiness" json:"happiness"`
+	Health    int     `yaml:"health" json:"health"`
+	Energy    int     `yaml:"energy" json:"energy"`
+	Weight    float64 `yaml:"weight" json:"weight"`
+	Coins     int     `yaml:"coins" json:"coins"`
+}
+
+// Lifespan is how long the pet lived.
+func (g GraveConfig) Lifespan() time.Duration {
+	return g.Died.Sub(g.Born)
+}
+
+func graveyardPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "graveyard.yml"), nil
+}
+
+// LoadGraveyard reads every archived pet, oldest first.
+func LoadGraveyard() ([]GraveConfig, error) {
+	path, err := graveyardPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var graves []GraveConfig
+	if err := yaml.Unmarshal(data, &graves); err != nil {
+		return nil, err
+	}
+	return graves, nil
+}
+
+// AddGrave archives a pet. A pet already in the graveyard is left as is.
+func AddGrave(grave GraveConfig) error {
+	graves, err := LoadGraveyard()
+	if err != nil {
+		return err
+	}
+
+	for _, g := range graves {
+		if g.ID == grave.ID {
+			return nil
+		}
+	}
+
+	path, err := graveyardPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(append(graves, grave))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
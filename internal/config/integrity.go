@@ -0,0 +1,112 @@
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The game state in config.yml is signed with an HMAC whose key is made on
+// the first save and kept next to it, so a save edited by hand no longer
+// matches its signature. So are the integrity settings, which say what that
+// costs. Other settings aren't signed: players are welcome to edit those.
+
+const keyFile = "install.key"
+
+// How a save checked out against its signature when it was loaded.
+const (
+	SignatureValid    = "valid"
+	SignatureUnsigned = "unsigned" // a new save
+	SignatureInvalid  = "invalid"
+)
+
+// IntegrityConfig is what happens when the save or the clock look tampered
+// with. It is signed along with the game state, so it can only be set before
+// the first save.
+type IntegrityConfig struct {
+	OnTamper   string        `yaml:"on_tamper"`   // log, flag or reset; flag when empty
+	OnClock    string        `yaml:"on_clock"`    // log, flag or reset; flag when empty
+	MaxOffline time.Duration `yaml:"max_offline"` // longer absences count as a clock jump; a year when zero
+}
+
+// installKey reads the signing key of this install, making a new one when
+// there is none, or a broken one, and create is set.
+func installKey(dir string, create bool) ([]byte, error) {
+	path := filepath.Join(dir, keyFile)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		key, decodeErr := hex.DecodeString(strings.TrimSpace(string(data)))
+		if decodeErr == nil && len(key) > 0 {
+			return key, nil
+		}
+		err = decodeErr
+	}
+	if !create {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// signedState is the part of the save the signature covers.
+func (c *Config) signedState() []byte {
+	state := struct {
+		LastLogin    time.Time          `yaml:"last_login"`
+		Vacation     VacationConfig     `yaml:"vacation"`
+		Pets         []TamagotchiConfig `yaml:"pets"`
+		Lineage      []LineageConfig    `yaml:"lineage"`
+		Achievements AchievementsConfig `yaml:"achievements"`
+		// Left out when unset, so saves signed before it was covered
+		// still match.
+		Integrity IntegrityConfig `yaml:"integrity,omitempty"`
+	}{c.App.LastLogin, c.App.Vacation, c.Pets, c.Lineage, c.Achievements, c.App.Integrity}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *Config) sign(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(c.signedState())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkSignature checks a save loaded from dir against its signature. Only a
+// new save may be unsigned: one with pets that has been played has had its
+// signature removed.
+func (c *Config) checkSignature(dir string) string {
+	key, err := installKey(dir, false)
+	switch {
+	case c.Signature == "" && len(c.Pets) > 0 && !c.App.LastLogin.IsZero():
+		return SignatureInvalid
+	case err != nil && c.Signature == "":
+		return SignatureUnsigned
+	case err != nil:
+		return SignatureInvalid // signed, but the key is gone
+	case !hmac.Equal([]byte(c.Signature), []byte(c.sign(key))):
+		return SignatureInvalid
+	default:
+		return SignatureValid
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckSignature(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SignatureCheck != SignatureUnsigned {
+		t.Fatalf("new save: %s, want %s", cfg.SignatureCheck, SignatureUnsigned)
+	}
+	cfg.App.LastLogin = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	cfg.Pets = []TamagotchiConfig{{ID: "abc", Name: "Rex", IsAlive: true}}
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.yml")
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edits := []struct {
+		name string
+		edit func(save string) string
+		want string
+	}{
+		{"untouched", func(save string) string { return save }, SignatureValid},
+		{"pets edited", func(save string) string { return strings.Replace(save, "name: Rex", "name: Max", 1) }, SignatureInvalid},
+		{"integrity edited", func(save string) string {
+			return strings.Replace(save, "on_tamper: \"\"", "on_tamper: log", 1)
+		}, SignatureInvalid},
+		{"signature removed", func(save string) string {
+			_ = os.Remove(filepath.Join(dir, keyFile))
+			var kept []string
+			for _, line := range strings.Split(save, "\n") {
+				if !strings.HasPrefix(line, "signature:") {
+					kept = append(kept, line)
+				}
+			}
+			return strings.Join(kept, "\n")
+		}, SignatureInvalid},
+	}
+	for _, e := range edits {
+		t.Run(e.name, func(t *testing.T) {
+			edited := e.edit(string(saved))
+			if e.want == SignatureInvalid && edited == string(saved) {
+				t.Fatal("the edit changed nothing")
+			}
+			if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := LoadConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.SignatureCheck != e.want {
+				t.Errorf("got %s, want %s", cfg.SignatureCheck, e.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	journalFile = "events.jsonl"
+
+	// The live journal is rotated into a timestamped archive once it grows
+	// past maxJournalSize. Archives are never deleted.
+	maxJournalSize   = 1 << 20
+	journalArchives  = "events-*.jsonl"
+	archiveTimestamp = "20060102T150405.000000000"
+)
+
+// EventRecord is one line of the event journal.
+type EventRecord struct {
+	Pet       string    `json:"pet"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Journal is an append-only JSON Lines log of game events.
+type Journal struct {
+	mu   sync.Mutex
+	dir  string
+	file *os.File
+	size int64
+}
+
+// OpenJournal opens the event journal in dir for appending.
+func OpenJournal(dir string) (*Journal, error) {
+	j := &Journal{dir: dir}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *Journal) open() error {
+	f, err := os.OpenFile(filepath.Join(j.dir, journalFile), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	j.file = f
+	j.size = info.Size()
+
+	// A crash mid-write leaves a partial last line. Drop it so the next
+	// record starts on a line of its own.
+	end, err := lastLineEnd(f, j.size)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if end != j.size {
+		if err := f.Truncate(end); err != nil {
+			f.Close()
+			return err
+		}
+		j.size = end
+	}
+
+	return nil
+}
+
+// lastLineEnd returns the offset just past the last newline in the first size
+// bytes of f, or 0 if there is none.
+func lastLineEnd(f *os.File, size int64) (int64, error) {
+	buf := make([]byte, 4096)
+	for end := size; end > 0; {
+		start := max(0, end-int64(len(buf)))
+		chunk := buf[:end-start]
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// Append writes a record to the journal, rotating it first if it is full.
+func (j *Journal) Append(rec EventRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return os.ErrClosed
+	}
+
+	if j.size > 0 && j.size+int64(len(line)) > maxJournalSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := j.file.Write(line)
+	j.size += int64(n)
+	return err
+}
+
+func (j *Journal) rotate() error {
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	j.file = nil
+
+	archive := fmt.Sprintf("events-%s.jsonl", time.Now().UTC().Format(archiveTimestamp))
+	if err := os.Rename(filepath.Join(j.dir, journalFile), filepath.Join(j.dir, archive)); err != nil {
+		return err
+	}
+
+	return j.open()
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// JournalFiles lists the journal files in dir, oldest first, ending with the
+// live journal.
+func JournalFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, journalArchives))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	live := filepath.Join(dir, journalFile)
+	if _, err := os.Stat(live); err == nil {
+		files = append(files, live)
+	}
+	return files, nil
+}
+
+// ReadJournal returns every record in dir, oldest first. Files and lines that
+// can't be read are skipped, and reported in the error returned along with the
+// rest of the records.
+func ReadJournal(dir string) ([]EventRecord, error) {
+	var files [][]EventRecord
+	err := ReadJournalNewestFirst(dir, func(records []EventRecord) bool {
+		files = append(files, records)
+		return true
+	})
+
+	var records []EventRecord
+	for i := len(files) - 1; i >= 0; i-- {
+		records = append(records, files[i]...)
+	}
+	return records, err
+}
+
+// ReadJournalNewestFirst calls visit with the records of each journal file in
+// dir, oldest first within a file, starting from the live journal and going
+// back through the archives until visit returns false. That way only as much
+// history as is needed gets read. Files and lines that can't be read are
+// skipped, and reported in the error returned.
+func ReadJournalNewestFirst(dir string, visit func(records []EventRecord) bool) error {
+	files, err := JournalFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := len(files) - 1; i >= 0; i-- {
+		records, err := ReadJournalFile(files[i])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !visit(records) {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReadJournalFile reads the records of a single journal file. A malformed
+// final line is what a crash mid-write leaves behind and is skipped quietly;
+// malformed lines anywhere else are skipped too, and reported in the error
+// returned along with the records read.
+func ReadJournalFile(path string) ([]EventRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		records []EventRecord
+		bad     []error
+		last    error // the latest malformed line, reported unless it is the final one
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJournalSize)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if last != nil {
+			bad = append(bad, last)
+			last = nil
+		}
+
+		var rec EventRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			last = fmt.Errorf("%s:%d: %w", filepath.Base(path), lineNo, err)
+			continue
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		bad = append(bad, fmt.Errorf("%s: %w", filepath.Base(path), err))
+	}
+	return records, errors.Join(bad...)
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadJournalSkipsBadLinesAndFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"events-20260101T000000.000000000.jsonl": `{"pet":"Rex","type":"FEED","message":"one"}` + "\n",
+		"events-20260201T000000.000000000.jsonl": "not json\n" + `{"pet":"Rex","type":"FEED","message":"two"}` + "\n",
+		journalFile:                              `{"pet":"Rex","type":"FEED","message":"three"}` + "\n" + `{"pet":"Rex","ty`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "events-20260301T000000.000000000.jsonl"), 0755); err != nil {
+		t.Fatal(err) // an archive that can't be read at all
+	}
+
+	records, err := ReadJournal(dir)
+	if err == nil {
+		t.Error("no error for the malformed line and the unreadable archive")
+	}
+	var got []string
+	for _, rec := range records {
+		got = append(got, rec.Message)
+	}
+	if want := []string{"one", "two", "three"}; !slices.Equal(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+
+	var visited []string
+	_ = ReadJournalNewestFirst(dir, func(records []EventRecord) bool {
+		for _, rec := range records {
+			visited = append(visited, rec.Message)
+		}
+		return len(visited) < 2
+	})
+	if want := []string{"three", "two"}; !slices.Equal(visited, want) {
+		t.Errorf("visited = %v, want %v", visited, want)
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// lockRefresh is how often the holder of the save lock touches it.
+	lockRefresh = 5 * time.Second
+
+	// lockStale is how long a save lock can go untouched before it is taken
+	// to be left over from a process that crashed.
+	lockStale = 30 * time.Second
+)
+
+// ErrSaveLocked is returned by LockSave while another process runs the game.
+var ErrSaveLocked = errors.New("the save is in use by another termagotchi")
+
+// SaveLock is the claim of a process running the game, the TUI or the
+// daemon, on the save, so that two of them don't both tick the pets and
+// write over each other's saves.
+type SaveLock struct {
+	path string
+	stop chan struct{}
+	once sync.Once
+}
+
+func lockPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "game.lock"), nil
+}
+
+// LockSave claims the save until Release, failing with ErrSaveLocked while
+// another process holds it.
+func LockSave() (*SaveLock, error) {
+	path, err := lockPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if errors.Is(err, os.ErrExist) {
+		info, statErr := os.Stat(path)
+		if statErr == nil && time.Since(info.ModTime()) < lockStale {
+			return nil, lockedError(path)
+		}
+		// Left over from a crash: its holder stopped touching it.
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if errors.Is(err, os.ErrExist) {
+			return nil, lockedError(path)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.WriteString(strconv.Itoa(os.Getpid()))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	l := &SaveLock{path: path, stop: make(chan struct{})}
+	go l.keep()
+	return l, nil
+}
+
+func lockedError(path string) error {
+	pid, err := os.ReadFile(path)
+	if err != nil || len(pid) == 0 {
+		return ErrSaveLocked
+	}
+	return fmt.Errorf("%w (process %s)", ErrSaveLocked, strings.TrimSpace(string(pid)))
+}
+
+// keep touches the lock so other processes know it is still held.
+func (l *SaveLock) keep() {
+	ticker := time.NewTicker(lockRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			_ = os.Chtimes(l.path, now, now)
+		}
+	}
+}
+
+// Release gives the save up.
+func (l *SaveLock) Release() error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		err = os.Remove(l.path)
+	})
+	return err
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLockSave(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	lock, err := LockSave()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LockSave(); !errors.Is(err, ErrSaveLocked) {
+		t.Fatalf("second LockSave: got %v, want ErrSaveLocked", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err = LockSave()
+	if err != nil {
+		t.Fatalf("LockSave after Release: %v", err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	// A lock nobody touched for a while is left over from a crash.
+	path, err := lockPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * lockStale)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	stolen, err := LockSave()
+	if err != nil {
+		t.Fatalf("LockSave over a stale lock: %v", err)
+	}
+	_ = stolen.Release()
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StatSample is the average of a pet's stats over one bucket of time.
+type StatSample struct {
+	Time      time.Time `json:"t"` // start of the bucket
+	Hunger    float64   `json:"hu"`
+	Happiness float64   `json:"ha"`
+	Health    float64   `json:"he"`
+	Energy    float64   `json:"en"`
+	Weight    float64   `json:"we"`
+	Count     int       `json:"n"` // ticks averaged into the bucket
+}
+
+// StatTier is a series of samples at one resolution.
+type StatTier struct {
+	Resolution time.Duration `json:"resolution"`
+	Samples    []StatSample  `json:"samples"`
+}
+
+func statsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "stats.json"), nil
+}
+
+// LoadStats reads the stat history of every pet, keyed by pet ID.
+func LoadStats() (map[string][]StatTier, error) {
+	path, err := statsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stats map[string][]StatTier
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// SaveStats writes the stat history of every pet, keyed by pet ID.
+func SaveStats(stats map[string][]StatTier) error {
+	path, err := statsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
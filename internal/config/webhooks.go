@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WebhookDelivery is a webhook post waiting to go out.
+type WebhookDelivery struct {
+	Webhook  string          `json:"webhook"` // name of the webhook
+	URL      string          `json:"url"`
+	Body     json.RawMessage `json:"body"`
+	Queued   time.Time       `json:"queued"`
+	Attempts int             `json:"attempts"`
+	Next     time.Time       `json:"next"` // when to try again
+}
+
+func webhookQueuePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "webhooks.json"), nil
+}
+
+// LoadWebhookQueue reads the webhook posts still to deliver.
+func LoadWebhookQueue() ([]WebhookDelivery, error) {
+	path, err := webhookQueuePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var queue []WebhookDelivery
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// SaveWebhookQueue writes the webhook posts still to deliver.
+func SaveWebhookQueue(queue []WebhookDelivery) error {
+	path, err := webhookQueuePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(queue)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
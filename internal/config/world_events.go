@@ -0,0 +1,101 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed world_events.yml
+var defaultWorldEvents []byte
+
+type WorldEventsConfig struct {
+	MeanInterval time.Duration      `yaml:"mean_interval"` // average time between events
+	Events       []WorldEventConfig `yaml:"events"`
+}
+
+type WorldEventConfig struct {
+	ID            string              `yaml:"id"`
+	Weight        int                 `yaml:"weight"`
+	Message       string              `yaml:"message"`
+	Summary       string              `yaml:"summary"`        // for the summary of time spent away
+	OfflineChoice int                 `yaml:"offline_choice"` // taken during offline catch-up
+	Choices       []WorldChoiceConfig `yaml:"choices"`
+}
+
+type WorldChoiceConfig struct {
+	Label   string        `yaml:"label"`
+	Message string        `yaml:"message"`
+	Effects EffectsConfig `yaml:"effects"`
+}
+
+// EffectsConfig are changes to a pet's stats and belongings.
+type EffectsConfig struct {
+	Hunger    int     `yaml:"hunger"`
+	Happiness int     `yaml:"happiness"`
+	Health    int     `yaml:"health"`
+	Energy    int     `yaml:"energy"`
+	Weight    float64 `yaml:"weight"`
+	Coins     int     `yaml:"coins"`
+	Item      string  `yaml:"item"`
+}
+
+// LoadWorldEvents reads world_events.yml from the save directory, or the
+// built-in events when there is none. A broken file is reported along with
+// the built-in events.
+func LoadWorldEvents() (WorldEventsConfig, error) {
+	defaults, err := parseWorldEvents(defaultWorldEvents)
+	if err != nil {
+		return WorldEventsConfig{}, err
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return defaults, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "world_events.yml"))
+	if os.IsNotExist(err) {
+		return defaults, nil
+	}
+	if err != nil {
+		return defaults, err
+	}
+
+	events, err := parseWorldEvents(data)
+	if err != nil {
+		return defaults, fmt.Errorf("world_events.yml: %w", err)
+	}
+	return events, nil
+}
+
+func parseWorldEvents(data []byte) (WorldEventsConfig, error) {
+	var events WorldEventsConfig
+	if err := yaml.Unmarshal(data, &events); err != nil {
+		return WorldEventsConfig{}, err
+	}
+
+	if events.MeanInterval <= 0 {
+		return WorldEventsConfig{}, fmt.Errorf("mean_interval must be positive")
+	}
+	for i := range events.Events {
+		e := &events.Events[i]
+		if e.Weight < 0 {
+			return WorldEventsConfig{}, fmt.Errorf("event %s: weight must not be negative", e.ID)
+		}
+		if len(e.Choices) == 0 {
+			return WorldEventsConfig{}, fmt.Errorf("event %s: needs at least one choice", e.ID)
+		}
+		if e.Summary == "" {
+			e.Summary = e.ID
+		}
+		if e.OfflineChoice < 0 || e.OfflineChoice >= len(e.Choices) {
+			return WorldEventsConfig{}, fmt.Errorf("event %s: offline_choice out of range", e.ID)
+		}
+	}
+	return events, nil
+}